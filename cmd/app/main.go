@@ -25,6 +25,14 @@ func main() {
 		os.Exit(1)
 	}
 
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		if err := app.Migrate(ctx, log, config, os.Args[2:], os.Stdout); err != nil {
+			log.Error("Failed to run migrations", zaplogger.Err(err))
+			os.Exit(1)
+		}
+		return
+	}
+
 	if err := app.Run(ctx, log, config); err != nil {
 		log.Error("Failed to Run application service", zaplogger.Err(err))
 		os.Exit(1)
@@ -6,10 +6,11 @@ import (
 	"internship/internal/config"
 	httpserver "internship/internal/http-server"
 	"internship/internal/http-server/handler"
-	"internship/internal/repository/postgres"
 	"internship/internal/service"
+	"internship/internal/worker"
 	"os"
 	"os/signal"
+	"sync"
 	"syscall"
 
 	"go.uber.org/zap"
@@ -23,35 +24,65 @@ func Run(ctx context.Context, log *zap.Logger, config config.ServiceConfig) erro
 	sigChan := make(chan os.Signal, 1)
 	signal.Notify(sigChan, syscall.SIGINT, syscall.SIGTERM)
 
-	storage, err := postgres.NewDatabase(ctx, config.DbConfig.DBConn)
+	repos, err := newRepositories(ctx, log, config.DbConfig)
 	if err != nil {
-		log.Error("Failed to connect to database", zap.Error(err))
-		return fmt.Errorf("database connection failed: %w", err)
+		return err
 	}
-	log.Info("Connected to database", zap.String("dsn", config.DbConfig.DBConn))
+	defer repos.close()
 
-	dbpool := storage.GetPool()
-	defer func() {
-		log.Info("Closing database connection...")
-		dbpool.Close()
-		log.Info("Database connection closed")
-	}()
+	teamService := service.NewTeamService(repos.team, repos.user, repos.audit, repos.txManager, log)
+	reviewerSelector, err := service.NewReviewerSelector(config.Reviewers, repos.reviewer)
+	if err != nil {
+		log.Error("Failed to create reviewer selector", zap.Error(err))
+		return fmt.Errorf("reviewer selector: %w", err)
+	}
 
-	teamRepo := postgres.NewTeamRepository(dbpool)
-	userRepo := postgres.NewUserRepository(dbpool)
-	reviewerRepo := postgres.NewReviewerRepository(dbpool)
-	prRepo := postgres.NewPullRequestRepository(dbpool, reviewerRepo)
-	statsRepo := postgres.NewStatisticsRepository(dbpool)
+	userService := service.NewUserService(repos.user, repos.team, repos.pr, repos.reviewer, repos.event, repos.audit, reviewerSelector, repos.txManager, config.Deactivation.FallbackTeam, log)
 
-	teamService := service.NewTeamService(teamRepo, userRepo, log)
-	userService := service.NewUserService(userRepo, prRepo, reviewerRepo, log)
-	pullRequestService := service.NewPullRequestService(prRepo, userRepo, reviewerRepo, log)
-	statisticsService := service.NewStatisticsService(statsRepo, log)
+	mergePolicy, err := service.NewMergePolicy(config.Merge)
+	if err != nil {
+		log.Error("Failed to create merge policy", zap.Error(err))
+		return fmt.Errorf("merge policy: %w", err)
+	}
 
-	handlers := handler.NewHandlers(teamService, userService, pullRequestService, statisticsService, log)
+	pullRequestService := service.NewPullRequestService(repos.pr, repos.team, repos.user, repos.reviewer, repos.review, repos.change, repos.event, repos.audit, reviewerSelector, mergePolicy, repos.txManager, log)
+	statisticsService := service.NewStatisticsService(repos.stats, log)
+	auditService := service.NewAuditService(repos.audit, config.Audit.Retention, log)
+
+	handlers := handler.NewHandlers(teamService, userService, pullRequestService, statisticsService, auditService, log)
 
 	server := httpserver.NewServer(log, config, handlers)
 
+	// Интервалы проверяются до запуска воркеров, чтобы ошибка не оставила запущенные горутины
+	if config.Backfill.Enabled && config.Backfill.Interval <= 0 {
+		return fmt.Errorf("backfill interval must be positive, got %s", config.Backfill.Interval)
+	}
+	if config.Audit.Retention > 0 && config.Audit.CleanupInterval <= 0 {
+		return fmt.Errorf("audit cleanup interval must be positive, got %s", config.Audit.CleanupInterval)
+	}
+
+	var wg sync.WaitGroup
+	defer func() {
+		cancel()
+		wg.Wait()
+	}()
+	if config.Backfill.Enabled {
+		backfillWorker := worker.NewBackfillWorker(pullRequestService, config.Backfill.Interval, log)
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			backfillWorker.Run(ctx)
+		}()
+	}
+	if config.Audit.Retention > 0 {
+		retentionWorker := worker.NewAuditRetentionWorker(auditService, config.Audit.CleanupInterval, log)
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			retentionWorker.Run(ctx)
+		}()
+	}
+
 	serverDone := make(chan error, 1)
 	go func() {
 		log.Info("Starting HTTP server...")
@@ -71,6 +102,7 @@ func Run(ctx context.Context, log *zap.Logger, config config.ServiceConfig) erro
 		}
 
 		log.Info("Waiting for goroutines to finish...")
+		wg.Wait()
 
 		log.Info("Application gracefully shut down")
 		return nil
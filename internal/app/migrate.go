@@ -0,0 +1,135 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"internship/internal/config"
+	"internship/internal/repository/postgres"
+	"internship/migrations"
+	"io"
+	"strconv"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"go.uber.org/zap"
+)
+
+// ErrMigrateUsage — неверные аргументы подкоманды migrate
+var ErrMigrateUsage = errors.New("usage: app migrate up | down [N] | status | version")
+
+// Migrate выполняет подкоманду migrate над базой PostgreSQL из конфигурации:
+// up — применить недостающие миграции, down [N] — откатить N последних (по умолчанию одну),
+// status — показать состояние миграций, version — показать текущую версию схемы
+func Migrate(ctx context.Context, log *zap.Logger, cfg config.ServiceConfig, args []string, out io.Writer) error {
+	if len(args) == 0 {
+		return ErrMigrateUsage
+	}
+	if cfg.DbConfig.Driver != config.DriverPostgres {
+		return fmt.Errorf("migrate supports only the %s driver, %s migrations are applied at startup", config.DriverPostgres, cfg.DbConfig.Driver)
+	}
+
+	storage, err := postgres.NewDatabase(ctx, cfg.DbConfig.DBConn)
+	if err != nil {
+		log.Error("Failed to connect to database", zap.Error(err))
+		return fmt.Errorf("database connection failed: %w", err)
+	}
+	defer storage.Close()
+
+	migrator, err := postgres.NewMigrator(storage.GetPool(), migrations.FS)
+	if err != nil {
+		return fmt.Errorf("load migrations: %w", err)
+	}
+
+	switch args[0] {
+	case "up":
+		if len(args) != 1 {
+			return ErrMigrateUsage
+		}
+		applied, err := migrator.Up(ctx)
+		for _, migration := range applied {
+			fmt.Fprintf(out, "applied %d_%s\n", migration.Version, migration.Name)
+		}
+		if err != nil {
+			return err
+		}
+		if len(applied) == 0 {
+			fmt.Fprintln(out, "no change")
+		}
+
+	case "down":
+		steps := 1
+		if len(args) > 2 {
+			return ErrMigrateUsage
+		}
+		if len(args) == 2 {
+			steps, err = strconv.Atoi(args[1])
+			if err != nil || steps <= 0 {
+				return ErrMigrateUsage
+			}
+		}
+		reverted, err := migrator.Down(ctx, steps)
+		for _, migration := range reverted {
+			fmt.Fprintf(out, "reverted %d_%s\n", migration.Version, migration.Name)
+		}
+		if err != nil {
+			return err
+		}
+		if len(reverted) == 0 {
+			fmt.Fprintln(out, "no change")
+		}
+
+	case "status":
+		if len(args) != 1 {
+			return ErrMigrateUsage
+		}
+		statuses, err := migrator.Status(ctx)
+		if err != nil {
+			return err
+		}
+		for _, status := range statuses {
+			state := "pending"
+			if status.Applied {
+				state = "applied"
+			}
+			fmt.Fprintf(out, "%-8s %d_%s\n", state, status.Version, status.Name)
+		}
+
+	case "version":
+		if len(args) != 1 {
+			return ErrMigrateUsage
+		}
+		version, dirty, err := migrator.Version(ctx)
+		if err != nil {
+			return err
+		}
+		if dirty {
+			fmt.Fprintf(out, "%d (dirty)\n", version)
+		} else {
+			fmt.Fprintln(out, version)
+		}
+
+	default:
+		return ErrMigrateUsage
+	}
+
+	return nil
+}
+
+// applyMigrations применяет недостающие миграции при старте сервиса
+func applyMigrations(ctx context.Context, log *zap.Logger, pool *pgxpool.Pool) error {
+	migrator, err := postgres.NewMigrator(pool, migrations.FS)
+	if err != nil {
+		return fmt.Errorf("load migrations: %w", err)
+	}
+
+	applied, err := migrator.Up(ctx)
+	for _, migration := range applied {
+		log.Info("Applied migration", zap.Int("version", migration.Version), zap.String("name", migration.Name))
+	}
+	if err != nil {
+		log.Error("Failed to apply migrations", zap.Error(err))
+		return fmt.Errorf("apply migrations: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,119 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"internship/internal/config"
+	"internship/internal/repository/memory"
+	"internship/internal/repository/postgres"
+	"internship/internal/repository/sqlite"
+	"internship/internal/service"
+
+	"go.uber.org/zap"
+)
+
+// repositories — набор репозиториев выбранного хранилища
+type repositories struct {
+	team      service.TeamRepositoryInterface
+	user      service.UserRepositoryInterface
+	reviewer  service.ReviewerRepositoryInterface
+	review    service.ReviewDecisionRepositoryInterface
+	change    service.PullRequestChangeRepositoryInterface
+	event     service.AssignmentEventRepositoryInterface
+	audit     service.AuditRepositoryInterface
+	pr        service.PullRequestRepositoryInterface
+	stats     service.StatisticsRepositoryInterface
+	txManager service.TransactionManager
+	// close освобождает ресурсы хранилища
+	close func()
+}
+
+// newRepositories создает репозитории для драйвера из конфигурации
+func newRepositories(ctx context.Context, log *zap.Logger, dbConfig config.DBConfig) (*repositories, error) {
+	switch dbConfig.Driver {
+	case config.DriverPostgres:
+		storage, err := postgres.NewDatabase(ctx, dbConfig.DBConn)
+		if err != nil {
+			log.Error("Failed to connect to database", zap.Error(err))
+			return nil, fmt.Errorf("database connection failed: %w", err)
+		}
+		log.Info("Connected to database", zap.String("dsn", dbConfig.DBConn))
+
+		dbpool := storage.GetPool()
+		if dbConfig.AutoMigrate {
+			if err := applyMigrations(ctx, log, dbpool); err != nil {
+				dbpool.Close()
+				return nil, err
+			}
+		}
+
+		return &repositories{
+			team:      postgres.NewTeamRepository(dbpool),
+			user:      postgres.NewUserRepository(dbpool),
+			reviewer:  postgres.NewReviewerRepository(dbpool),
+			review:    postgres.NewReviewDecisionRepository(dbpool),
+			change:    postgres.NewPullRequestChangeRepository(dbpool),
+			event:     postgres.NewAssignmentEventRepository(dbpool),
+			audit:     postgres.NewAuditRepository(dbpool),
+			pr:        postgres.NewPullRequestRepository(dbpool),
+			stats:     postgres.NewStatisticsRepository(dbpool),
+			txManager: postgres.NewTxManager(dbpool),
+			close: func() {
+				log.Info("Closing database connection...")
+				dbpool.Close()
+				log.Info("Database connection closed")
+			},
+		}, nil
+
+	case config.DriverSQLite:
+		storage, err := sqlite.NewDatabase(ctx, dbConfig.Path)
+		if err != nil {
+			log.Error("Failed to open database", zap.Error(err))
+			return nil, fmt.Errorf("database open failed: %w", err)
+		}
+		log.Info("Opened database", zap.String("path", dbConfig.Path))
+
+		db := storage.GetDB()
+		return &repositories{
+			team:      sqlite.NewTeamRepository(db),
+			user:      sqlite.NewUserRepository(db),
+			reviewer:  sqlite.NewReviewerRepository(db),
+			review:    sqlite.NewReviewDecisionRepository(db),
+			change:    sqlite.NewPullRequestChangeRepository(db),
+			event:     sqlite.NewAssignmentEventRepository(db),
+			audit:     sqlite.NewAuditRepository(db),
+			pr:        sqlite.NewPullRequestRepository(db),
+			stats:     sqlite.NewStatisticsRepository(db),
+			txManager: sqlite.NewTxManager(db),
+			close: func() {
+				log.Info("Closing database...")
+				if err := storage.Close(); err != nil {
+					log.Error("Failed to close database", zap.Error(err))
+					return
+				}
+				log.Info("Database closed")
+			},
+		}, nil
+
+	case config.DriverMemory:
+		log.Warn("Using in-memory storage, data will be lost on shutdown")
+
+		storage := memory.NewStorage()
+		return &repositories{
+			team:      memory.NewTeamRepository(storage),
+			user:      memory.NewUserRepository(storage),
+			reviewer:  memory.NewReviewerRepository(storage),
+			review:    memory.NewReviewDecisionRepository(storage),
+			change:    memory.NewPullRequestChangeRepository(storage),
+			event:     memory.NewAssignmentEventRepository(storage),
+			audit:     memory.NewAuditRepository(storage),
+			pr:        memory.NewPullRequestRepository(storage),
+			stats:     memory.NewStatisticsRepository(storage),
+			txManager: memory.NewTxManager(storage),
+			close:     func() {},
+		}, nil
+
+	default:
+		return nil, fmt.Errorf("unknown database driver %q", dbConfig.Driver)
+	}
+}
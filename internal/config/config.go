@@ -14,6 +14,7 @@ func LoadServiceConfig(log *zap.Logger, configPath, dbPasswordPath string) (Serv
 	v := viper.New()
 	v.SetConfigType("yaml")
 	v.SetConfigFile(configPath)
+	v.SetDefault("database.auto_migrate", true)
 	if err := v.ReadInConfig(); err != nil {
 		log.Error("Failed to Read config", zaplogger.Err(err))
 		return ServiceConfig{}, err
@@ -24,12 +25,15 @@ func LoadServiceConfig(log *zap.Logger, configPath, dbPasswordPath string) (Serv
 		log.Error("Failed to Unmarshal config", zaplogger.Err(err))
 		return ServiceConfig{}, err
 	}
-	dbConnStr, err := serviceConfig.DSN(log, dbPasswordPath)
-	if err != nil {
-		log.Error("Error generating DSN for database connection", zaplogger.Err(err))
-		return ServiceConfig{}, err
+	// Строка подключения нужна только внешней БД
+	if serviceConfig.DbConfig.Driver == DriverPostgres {
+		dbConnStr, err := serviceConfig.DSN(log, dbPasswordPath)
+		if err != nil {
+			log.Error("Error generating DSN for database connection", zaplogger.Err(err))
+			return ServiceConfig{}, err
+		}
+		serviceConfig.DbConfig.DBConn = dbConnStr
 	}
-	serviceConfig.DbConfig.DBConn = dbConnStr
 	log.Info("Config", zap.Any("serviceConfig", serviceConfig))
 	return serviceConfig, nil
 }
@@ -45,16 +49,33 @@ func (d ServiceConfig) DSN(log *zap.Logger, dbPasswordPath string) (string, erro
 }
 
 type ServiceConfig struct {
-	Server   ServerConfig `mapstructure:"server"`
-	DbConfig DBConfig     `mapstructure:"database"`
+	Server       ServerConfig       `mapstructure:"server"`
+	DbConfig     DBConfig           `mapstructure:"database"`
+	Reviewers    ReviewersConfig    `mapstructure:"reviewers"`
+	Backfill     BackfillConfig     `mapstructure:"backfill"`
+	Deactivation DeactivationConfig `mapstructure:"deactivation"`
+	Merge        MergeConfig        `mapstructure:"merge"`
+	Audit        AuditConfig        `mapstructure:"audit"`
 }
+
+// Поддерживаемые хранилища данных
+const (
+	DriverPostgres = "postgres"
+	DriverMemory   = "memory"
+	DriverSQLite   = "sqlite"
+)
+
 type DBConfig struct {
 	Driver string `yaml:"driver"`
 	Host   string `yaml:"host"`
 	Port   int    `yaml:"port"`
 	User   string `yaml:"user"`
 	DBName string `yaml:"dbname"`
-	DBConn string
+	// Path — путь к файлу базы для драйвера sqlite
+	Path string `yaml:"path"`
+	// AutoMigrate — применять недостающие миграции PostgreSQL при старте сервиса
+	AutoMigrate bool `mapstructure:"auto_migrate"`
+	DBConn      string
 }
 type ServerConfig struct {
 	Address      string        `yaml:"address"`
@@ -62,3 +83,52 @@ type ServerConfig struct {
 	WriteTimeout time.Duration `yaml:"writeTimeout"`
 	IdleTimeout  time.Duration `yaml:"idleTimeout"`
 }
+
+// DeactivationConfig задает параметры массовой деактивации команды
+type DeactivationConfig struct {
+	FallbackTeam string `mapstructure:"fallback_team"`
+}
+
+// MergeConfig задает политику merge для сервиса и отдельных команд
+type MergeConfig struct {
+	RequiredApprovals int               `mapstructure:"required_approvals"`
+	Teams             []TeamMergeConfig `mapstructure:"teams"`
+}
+
+// TeamMergeConfig переопределяет политику merge для команды автора PR
+type TeamMergeConfig struct {
+	TeamName          string `mapstructure:"team_name"`
+	RequiredApprovals int    `mapstructure:"required_approvals"`
+}
+
+// AuditConfig задает хранение журнала аудита.
+// Retention — срок хранения записей (0 — бессрочно), CleanupInterval — период удаления устаревших записей
+type AuditConfig struct {
+	Retention       time.Duration `mapstructure:"retention"`
+	CleanupInterval time.Duration `mapstructure:"cleanup_interval"`
+}
+
+// BackfillConfig задает работу фонового доназначения ревьюверов
+type BackfillConfig struct {
+	Enabled  bool          `mapstructure:"enabled"`
+	Interval time.Duration `mapstructure:"interval"`
+}
+
+// ReviewersConfig задает стратегию выбора ревьюверов для сервиса и отдельных команд
+type ReviewersConfig struct {
+	Strategy string                 `mapstructure:"strategy"`
+	Teams    []TeamReviewersConfig  `mapstructure:"teams"`
+	Weights  []ReviewerWeightConfig `mapstructure:"weights"`
+}
+
+// TeamReviewersConfig переопределяет стратегию выбора для конкретной команды
+type TeamReviewersConfig struct {
+	TeamName string `mapstructure:"team_name"`
+	Strategy string `mapstructure:"strategy"`
+}
+
+// ReviewerWeightConfig задает вес пользователя для стратегии weighted
+type ReviewerWeightConfig struct {
+	UserID string `mapstructure:"user_id"`
+	Weight int    `mapstructure:"weight"`
+}
@@ -0,0 +1,26 @@
+package entity
+
+import "context"
+
+const (
+	// ActorSystem — инициатор изменений, которые сервис выполняет сам, например фоновым воркером
+	ActorSystem = "system"
+	// ActorAnonymous — инициатор запроса к API, не представившийся заголовком X-Actor-ID
+	ActorAnonymous = "anonymous"
+)
+
+// actorKey — ключ контекста, под которым хранится инициатор операции
+type actorKey struct{}
+
+// WithActor возвращает контекст с инициатором операции
+func WithActor(ctx context.Context, actor string) context.Context {
+	return context.WithValue(ctx, actorKey{}, actor)
+}
+
+// ActorFromContext возвращает инициатора операции; без него операция считается выполненной сервисом
+func ActorFromContext(ctx context.Context) string {
+	if actor, ok := ctx.Value(actorKey{}).(string); ok && actor != "" {
+		return actor
+	}
+	return ActorSystem
+}
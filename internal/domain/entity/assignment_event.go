@@ -0,0 +1,40 @@
+package entity
+
+import "time"
+
+// AssignmentEventType представляет тип изменения состава ревьюверов PR
+type AssignmentEventType string
+
+const (
+	AssignmentEventAssign   AssignmentEventType = "ASSIGN"
+	AssignmentEventUnassign AssignmentEventType = "UNASSIGN"
+	AssignmentEventReplace  AssignmentEventType = "REPLACE"
+)
+
+// AssignmentReason объясняет, почему изменился состав ревьюверов PR
+type AssignmentReason string
+
+const (
+	AssignmentReasonAutoCreate       AssignmentReason = "AUTO_CREATE"
+	AssignmentReasonManualReassign   AssignmentReason = "MANUAL_REASSIGN"
+	AssignmentReasonManualAssign     AssignmentReason = "MANUAL_ASSIGN"
+	AssignmentReasonManualRemove     AssignmentReason = "MANUAL_REMOVE"
+	AssignmentReasonTeamDeactivation AssignmentReason = "TEAM_DEACTIVATION"
+	AssignmentReasonBackfill         AssignmentReason = "BACKFILL"
+	AssignmentReasonMarkReady        AssignmentReason = "MARK_READY"
+	AssignmentReasonReopen           AssignmentReason = "REOPEN"
+	// AssignmentReasonImported — назначение, существовавшее до появления истории
+	AssignmentReasonImported AssignmentReason = "IMPORTED"
+)
+
+// ReviewerAssignmentEvent описывает изменение состава ревьюверов PR.
+// Для REPLACE UserID — новый ревьювер, PreviousUserID — замененный
+type ReviewerAssignmentEvent struct {
+	PullRequestID  string              `json:"pull_request_id"`
+	Event          AssignmentEventType `json:"event"`
+	UserID         string              `json:"user_id"`
+	PreviousUserID string              `json:"previous_user_id,omitempty"`
+	Reason         AssignmentReason    `json:"reason"`
+	Actor          string              `json:"actor"`
+	CreatedAt      time.Time           `json:"created_at"`
+}
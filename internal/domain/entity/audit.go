@@ -0,0 +1,65 @@
+package entity
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// AuditAction представляет изменяющую операцию API, записанную в журнал аудита
+type AuditAction string
+
+const (
+	AuditActionTeamAdd        AuditAction = "TEAM_ADD"
+	AuditActionSetIsActive    AuditAction = "USER_SET_IS_ACTIVE"
+	AuditActionDeactivateTeam AuditAction = "TEAM_DEACTIVATE"
+	AuditActionPRCreate       AuditAction = "PR_CREATE"
+	AuditActionPRMerge        AuditAction = "PR_MERGE"
+	AuditActionPRReassign     AuditAction = "PR_REASSIGN"
+	AuditActionPRReview       AuditAction = "PR_REVIEW"
+	AuditActionPRReady        AuditAction = "PR_READY"
+	AuditActionPRClose        AuditAction = "PR_CLOSE"
+	AuditActionPRReopen       AuditAction = "PR_REOPEN"
+	AuditActionPRUpdate       AuditAction = "PR_UPDATE"
+	AuditActionPRAssign       AuditAction = "PR_ADD_REVIEWER"
+	AuditActionPRUnassign     AuditAction = "PR_REMOVE_REVIEWER"
+	AuditActionTeamSettings   AuditAction = "TEAM_UPDATE_SETTINGS"
+	AuditActionTeamCodeOwners AuditAction = "TEAM_SET_CODE_OWNERS"
+	AuditActionSetExpertise   AuditAction = "USER_SET_EXPERTISE"
+)
+
+// AuditEntityType представляет тип сущности, измененной операцией
+type AuditEntityType string
+
+const (
+	AuditEntityTeam        AuditEntityType = "TEAM"
+	AuditEntityUser        AuditEntityType = "USER"
+	AuditEntityPullRequest AuditEntityType = "PULL_REQUEST"
+)
+
+// AuditRecord описывает изменение одной сущности операцией API.
+// Before и After — JSON состояния сущности до и после операции, null — сущность не существовала
+type AuditRecord struct {
+	ID         int64           `json:"id"`
+	Action     AuditAction     `json:"action"`
+	EntityType AuditEntityType `json:"entity_type"`
+	EntityID   string          `json:"entity_id"`
+	Actor      string          `json:"actor"`
+	RequestID  string          `json:"request_id"`
+	Before     json.RawMessage `json:"before"`
+	After      json.RawMessage `json:"after"`
+	CreatedAt  time.Time       `json:"created_at"`
+}
+
+// AuditFilter задает отбор записей журнала аудита. Пустые поля не ограничивают выборку.
+// Записи возвращаются от новых к старым; BeforeID продолжает выборку с записей старше указанной
+type AuditFilter struct {
+	Actor      string
+	RequestID  string
+	Action     AuditAction
+	EntityType AuditEntityType
+	EntityID   string
+	From       *time.Time
+	To         *time.Time
+	BeforeID   int64
+	Limit      int
+}
@@ -0,0 +1,24 @@
+package entity
+
+// CodeOwnerRule сопоставляет шаблон путей владельцам кода, как строка файла CODEOWNERS.
+// Шаблон с "/" в начале или в середине привязан к корню репозитория, без "/" — совпадает с именем на любой глубине,
+// "/" в конце совпадает только с каталогом; "*" заменяет любые символы внутри одного сегмента пути, "**" — любые сегменты
+type CodeOwnerRule struct {
+	Pattern string   `json:"pattern"`
+	Owners  []string `json:"owners"`
+}
+
+// CodeOwners — правила владельцев кода команды. Правила применяются к PR авторов команды;
+// если с файлом совпадает несколько правил, действует последнее
+type CodeOwners struct {
+	TeamName string          `json:"team_name"`
+	Rules    []CodeOwnerRule `json:"rules"`
+}
+
+// Ограничения правил владельцев кода
+const (
+	// MaxCodeOwnerRules — наибольшее число правил команды
+	MaxCodeOwnerRules = 100
+	// MaxRuleOwners — наибольшее число владельцев в одном правиле
+	MaxRuleOwners = 20
+)
@@ -10,9 +10,22 @@ var (
 	ErrPRExists     = errors.New("pull request already exists")
 	ErrPRNotFound   = errors.New("pull request not found")
 	ErrPRMerged     = errors.New("cannot modify merged pull request")
+	ErrPRClosed     = errors.New("cannot modify closed pull request")
+	ErrPRDraft      = errors.New("pull request is a draft")
 	ErrNotAssigned  = errors.New("user is not assigned to this pull request")
 	ErrNoCandidate  = errors.New("no active replacement candidate available")
 	ErrInvalidInput = errors.New("invalid input data")
+
+	ErrVersionConflict = errors.New("pull request was modified concurrently")
+	ErrMergeBlocked    = errors.New("merge conditions are not met")
+
+	// Нарушения правил назначения ревьювера
+	ErrAlreadyAssigned    = errors.New("user is already assigned to this pull request")
+	ErrAuthorReviewer     = errors.New("author cannot review own pull request")
+	ErrUserInactive       = errors.New("user is inactive")
+	ErrReviewerLimit      = errors.New("pull request already has the maximum number of reviewers")
+	ErrReviewerAtCapacity = errors.New("user reached max open reviews")
+	ErrTeamMismatch       = errors.New("user is not a member of the replaced reviewer's team")
 )
 
 // ErrorCode представляет код ошибки API
@@ -22,15 +35,29 @@ const (
 	CodeTeamExists  ErrorCode = "TEAM_EXISTS"
 	CodePRExists    ErrorCode = "PR_EXISTS"
 	CodePRMerged    ErrorCode = "PR_MERGED"
+	CodePRClosed    ErrorCode = "PR_CLOSED"
+	CodePRDraft     ErrorCode = "PR_DRAFT"
 	CodeNotAssigned ErrorCode = "NOT_ASSIGNED"
 	CodeNoCandidate ErrorCode = "NO_CANDIDATE"
 	CodeNotFound    ErrorCode = "NOT_FOUND"
+	CodeConflict    ErrorCode = "CONFLICT"
+
+	CodeMergeBlocked ErrorCode = "MERGE_BLOCKED"
+
+	CodeAlreadyAssigned    ErrorCode = "ALREADY_ASSIGNED"
+	CodeAuthorReviewer     ErrorCode = "AUTHOR_NOT_ALLOWED"
+	CodeUserInactive       ErrorCode = "USER_INACTIVE"
+	CodeReviewerLimit      ErrorCode = "REVIEWER_LIMIT"
+	CodeReviewerAtCapacity ErrorCode = "CAPACITY_REACHED"
+	CodeTeamMismatch       ErrorCode = "TEAM_MISMATCH"
 )
 
 // APIError представляет структурированную ошибку API
 type APIError struct {
 	Code    ErrorCode //`json:"code"`
 	Message string    //`json:"message"`
+	// Details уточняет ошибку, например перечисляет невыполненные условия merge
+	Details any `json:",omitempty"`
 }
 
 // ErrorResponse представляет ответ с ошибкой
@@ -0,0 +1,43 @@
+package entity
+
+import (
+	"fmt"
+	"strings"
+)
+
+// MergeCondition представляет условие, которое должно выполняться для merge PR
+type MergeCondition string
+
+const (
+	// MergeConditionMinReviewers — на PR назначено не меньше min_reviewers ревьюверов команды автора
+	MergeConditionMinReviewers MergeCondition = "MIN_REVIEWERS"
+	// MergeConditionApprovals — PR одобрен требуемым числом назначенных ревьюверов
+	MergeConditionApprovals MergeCondition = "REQUIRED_APPROVALS"
+	// MergeConditionNoChangesRequested — ни один назначенный ревьювер не запрашивает изменения
+	MergeConditionNoChangesRequested MergeCondition = "NO_CHANGES_REQUESTED"
+)
+
+// UnmetMergeCondition описывает невыполненное условие merge
+type UnmetMergeCondition struct {
+	Condition MergeCondition `json:"condition"`
+	Message   string         `json:"message"`
+	// Reviewers — ревьюверы, от которых зависит выполнение условия
+	Reviewers []string `json:"reviewers,omitempty"`
+}
+
+// MergeBlockedError возвращается, когда PR не удовлетворяет политике merge
+type MergeBlockedError struct {
+	Unmet []UnmetMergeCondition
+}
+
+func (e *MergeBlockedError) Error() string {
+	conditions := make([]string, 0, len(e.Unmet))
+	for _, unmet := range e.Unmet {
+		conditions = append(conditions, unmet.Message)
+	}
+	return fmt.Sprintf("%s: %s", ErrMergeBlocked, strings.Join(conditions, "; "))
+}
+
+func (e *MergeBlockedError) Unwrap() error {
+	return ErrMergeBlocked
+}
@@ -1,24 +1,87 @@
 package entity
 
-import "time"
+import (
+	"encoding/json"
+	"time"
+)
 
 // PRStatus представляет статус Pull Request
 type PRStatus string
 
 const (
+	// PRStatusDraft — черновик: ревьюверы не назначаются, пока PR не отмечен готовым
+	PRStatusDraft  PRStatus = "DRAFT"
 	PRStatusOpen   PRStatus = "OPEN"
 	PRStatusMerged PRStatus = "MERGED"
+	// PRStatusClosed — PR закрыт без merge; его можно переоткрыть
+	PRStatusClosed PRStatus = "CLOSED"
+)
+
+// ShortageReason объясняет, почему назначено меньше ревьюверов, чем требуется
+type ShortageReason string
+
+const (
+	ShortageNoActiveCandidates ShortageReason = "NO_ACTIVE_CANDIDATES"
+	ShortageCapacityReached    ShortageReason = "CAPACITY_REACHED"
 )
 
 // PullRequest представляет Pull Request
 type PullRequest struct {
-	PullRequestID     string     `json:"pull_request_id" db:"pull_request_id"`
-	PullRequestName   string     `json:"pull_request_name" db:"pull_request_name"`
-	AuthorID          string     `json:"author_id" db:"author_id"`
-	Status            PRStatus   `json:"status" db:"status"`
-	AssignedReviewers []string   `json:"assigned_reviewers" db:"-"`
-	CreatedAt         *time.Time `json:"createdAt,omitempty" db:"created_at"`
-	MergedAt          *time.Time `json:"mergedAt,omitempty" db:"merged_at"`
+	PullRequestID     string            `json:"pull_request_id" db:"pull_request_id"`
+	PullRequestName   string            `json:"pull_request_name" db:"pull_request_name"`
+	Description       string            `json:"description" db:"description"`
+	Labels            []string          `json:"labels" db:"labels"`
+	Metadata          map[string]string `json:"metadata" db:"metadata"`
+	AuthorID          string            `json:"author_id" db:"author_id"`
+	Status            PRStatus          `json:"status" db:"status"`
+	AssignedReviewers []string          `json:"assigned_reviewers" db:"-"`
+	CreatedAt         *time.Time        `json:"createdAt,omitempty" db:"created_at"`
+	MergedAt          *time.Time        `json:"mergedAt,omitempty" db:"merged_at"`
+	NeedMoreReviewers bool              `json:"needMoreReviewers" db:"need_more_reviewers"`
+	Version           int               `json:"version" db:"version"`
+	ShortageReason    ShortageReason    `json:"shortage_reason,omitempty" db:"-"`
+	// Reviews — решения ревьюверов в порядке поступления
+	Reviews []ReviewDecision `json:"reviews" db:"-"`
+	// ChangedFiles — пути измененных файлов; задаются при создании и сопоставляются с правилами владельцев кода
+	ChangedFiles []string `json:"changed_files" db:"changed_files"`
+	// RequiredTags — области экспертизы, которые нужны для ревью; задаются при создании
+	RequiredTags []string `json:"required_tags" db:"required_tags"`
+	// ReviewerTeams — команды, из которых назначены ревьюверы в ответ на запрос; не хранится
+	ReviewerTeams map[string]string `json:"reviewer_teams,omitempty" db:"-"`
+	// ReviewerRules — шаблоны правил владельцев кода, по которым назначены ревьюверы в ответ на запрос; не хранится
+	ReviewerRules map[string]string `json:"reviewer_rules,omitempty" db:"-"`
+}
+
+// Редактируемые поля PR
+const (
+	PRFieldName        = "pull_request_name"
+	PRFieldDescription = "description"
+	PRFieldLabels      = "labels"
+	PRFieldMetadata    = "metadata"
+)
+
+// PullRequestUpdate описывает правку полей PR, включая произвольные метаданные автора.
+// nil означает, что поле не меняется
+type PullRequestUpdate struct {
+	PullRequestName *string
+	Description     *string
+	Labels          []string
+	Metadata        map[string]string
+}
+
+// PullRequestChange описывает изменение поля PR: прежнее и новое значения хранятся в JSON
+type PullRequestChange struct {
+	Field     string          `json:"field"`
+	OldValue  json.RawMessage `json:"old_value"`
+	NewValue  json.RawMessage `json:"new_value"`
+	ChangedAt time.Time       `json:"changed_at"`
+}
+
+// ReviewerReassignment описывает изменение состава ревьюверов PR
+type ReviewerReassignment struct {
+	PullRequest      PullRequest `json:"pr"`
+	RemovedReviewers []string    `json:"removed_reviewers"`
+	AddedReviewers   []string    `json:"added_reviewers"`
 }
 
 // PullRequestShort представляет краткую информацию о PR
@@ -0,0 +1,30 @@
+package entity
+
+import "time"
+
+// ReviewDecisionType представляет решение ревьювера по PR
+type ReviewDecisionType string
+
+const (
+	ReviewApproved         ReviewDecisionType = "APPROVED"
+	ReviewChangesRequested ReviewDecisionType = "CHANGES_REQUESTED"
+	ReviewCommented        ReviewDecisionType = "COMMENTED"
+)
+
+// IsValid проверяет, что решение входит в список допустимых
+func (d ReviewDecisionType) IsValid() bool {
+	switch d {
+	case ReviewApproved, ReviewChangesRequested, ReviewCommented:
+		return true
+	}
+	return false
+}
+
+// ReviewDecision представляет решение ревьювера по PR.
+// Решения накапливаются: повторное ревью добавляет новую запись, а не заменяет прежнюю
+type ReviewDecision struct {
+	ReviewerID string             `json:"reviewer_id" db:"reviewer_id"`
+	Decision   ReviewDecisionType `json:"decision" db:"decision"`
+	Comment    string             `json:"comment,omitempty" db:"comment"`
+	CreatedAt  time.Time          `json:"created_at" db:"created_at"`
+}
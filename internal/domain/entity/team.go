@@ -4,11 +4,54 @@ package entity
 type Team struct {
 	TeamName string       `json:"team_name" db:"team_name"`
 	Members  []TeamMember `json:"members" db:"-"`
+	// DefaultMaxOpenReviews — лимит открытых ревью по умолчанию для участников, nil — без лимита
+	DefaultMaxOpenReviews *int `json:"default_max_open_reviews,omitempty" db:"default_max_open_reviews"`
+	ReviewerSettings
+	// FallbackTeams — резервные команды ревьюверов в порядке обращения к ним
+	FallbackTeams []string `json:"fallback_teams,omitempty" db:"-"`
 }
 
 // TeamMember представляет участника команды в составе команды
 type TeamMember struct {
-	UserID   string `json:"user_id"`
-	Username string `json:"username"`
-	IsActive bool   `json:"is_active"`
+	UserID         string `json:"user_id"`
+	Username       string `json:"username"`
+	IsActive       bool   `json:"is_active"`
+	MaxOpenReviews *int   `json:"max_open_reviews,omitempty"`
+	// ExpertiseTags — области экспертизы участника; если не переданы, у существующего пользователя не меняются
+	ExpertiseTags []string `json:"expertise_tags,omitempty"`
+}
+
+// Ограничения настроек команды
+const (
+	// MaxReviewersLimit — наибольшее допустимое число ревьюверов на PR
+	MaxReviewersLimit = 10
+	// MaxFallbackTeams — наибольшее число резервных команд
+	MaxFallbackTeams = 5
+)
+
+// ReviewerSettings задает число ревьюверов на PR авторов команды
+type ReviewerSettings struct {
+	// MinReviewers — число назначенных ревьюверов, без которого PR нельзя слить
+	MinReviewers int `json:"min_reviewers" db:"min_reviewers"`
+	// MaxReviewers — число ревьюверов, назначаемых автоматически, и предел ручного назначения
+	MaxReviewers int `json:"max_reviewers" db:"max_reviewers"`
+}
+
+// DefaultReviewerSettings — настройки новой команды: два ревьювера без требования к merge
+var DefaultReviewerSettings = ReviewerSettings{MinReviewers: 0, MaxReviewers: 2}
+
+// TeamSettings — настройки назначения ревьюверов на PR авторов команды
+type TeamSettings struct {
+	ReviewerSettings
+	// FallbackTeams — команды, из которых по порядку добираются ревьюверы, если в команде автора
+	// не хватает кандидатов
+	FallbackTeams []string `json:"fallback_teams"`
+}
+
+// TeamSettingsUpdate — изменение настроек команды, nil-поля не меняются.
+// Пустой FallbackTeams удаляет резервные команды
+type TeamSettingsUpdate struct {
+	MinReviewers  *int
+	MaxReviewers  *int
+	FallbackTeams []string
 }
@@ -6,4 +6,8 @@ type User struct {
 	Username string `json:"username" db:"username"`
 	TeamName string `json:"team_name" db:"team_name"`
 	IsActive bool   `json:"is_active" db:"is_active"`
+	// MaxOpenReviews — лимит открытых ревью пользователя, nil — используется лимит команды
+	MaxOpenReviews *int `json:"max_open_reviews,omitempty" db:"max_open_reviews"`
+	// ExpertiseTags — области экспертизы пользователя (например go, sql, frontend)
+	ExpertiseTags []string `json:"expertise_tags" db:"expertise_tags"`
 }
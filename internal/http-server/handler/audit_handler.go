@@ -0,0 +1,59 @@
+package handler
+
+import (
+	"errors"
+	"internship/internal/domain/entity"
+	"internship/internal/models/dto"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+type AuditHandler struct {
+	auditService AuditServiceInterface
+	log          *zap.Logger
+}
+
+func NewAuditHandler(auditService AuditServiceInterface, log *zap.Logger) *AuditHandler {
+	return &AuditHandler{
+		auditService: auditService,
+		log:          log,
+	}
+}
+
+// @Tags Audit
+// @Summary Получить записи журнала аудита по фильтру от новых к старым
+func (h *AuditHandler) GetAuditRecords(c *gin.Context) {
+	var query dto.AuditQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		h.log.Error("invalid audit query", zap.Error(err))
+		respondError(c, http.StatusBadRequest, entity.CodeNotFound, "invalid query parameters")
+		return
+	}
+
+	filter := entity.AuditFilter{
+		Actor:      query.Actor,
+		RequestID:  query.RequestID,
+		Action:     entity.AuditAction(query.Action),
+		EntityType: entity.AuditEntityType(query.EntityType),
+		EntityID:   query.EntityID,
+		From:       query.From,
+		To:         query.To,
+		BeforeID:   query.BeforeID,
+		Limit:      query.Limit,
+	}
+	records, err := h.auditService.ListRecords(c.Request.Context(), filter)
+	if err != nil {
+		if errors.Is(err, entity.ErrInvalidInput) {
+			h.log.Error("invalid audit filter", zap.Error(err))
+			respondError(c, http.StatusBadRequest, entity.CodeNotFound, err.Error())
+			return
+		}
+		h.log.Error("failed to get audit records", zap.Error(err))
+		respondError(c, http.StatusInternalServerError, entity.CodeNotFound, "failed to get audit records")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"records": records})
+}
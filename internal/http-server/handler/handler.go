@@ -7,13 +7,15 @@ type Handlers struct {
 	UserHandler        *UserHandler
 	PullRequestHandler *PullRequestHandler
 	StatisticsHandler  *StatisticsHandler
+	AuditHandler       *AuditHandler
 }
 
-func NewHandlers(teamService TeamServiceInterface, userService UserServiceInterface, pullRequestService PullRequestServiceInterface, statisticsService StatisticsServiceInterface, log *zap.Logger) *Handlers {
+func NewHandlers(teamService TeamServiceInterface, userService UserServiceInterface, pullRequestService PullRequestServiceInterface, statisticsService StatisticsServiceInterface, auditService AuditServiceInterface, log *zap.Logger) *Handlers {
 	return &Handlers{
 		TeamHandler:        NewTeamHandler(teamService, log),
 		UserHandler:        NewUserHandler(userService, log),
 		PullRequestHandler: NewPullRequestHandler(pullRequestService, log),
 		StatisticsHandler:  NewStatisticsHandler(statisticsService, log),
+		AuditHandler:       NewAuditHandler(auditService, log),
 	}
 }
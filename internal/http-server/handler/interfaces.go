@@ -7,20 +7,34 @@ import (
 
 type PullRequestServiceInterface interface {
 	CreatePullRequest(ctx context.Context, pr *entity.PullRequest) (*entity.PullRequest, error)
-	MergePullRequest(ctx context.Context, prID string) (*entity.PullRequest, error)
-	ReassignReviewer(ctx context.Context, prID, oldUserID string) (*entity.PullRequest, string, error)
+	MergePullRequest(ctx context.Context, prID string, expectedVersion *int) (*entity.PullRequest, error)
+	ReassignReviewer(ctx context.Context, prID, oldUserID, newUserID string, expectedVersion *int) (*entity.PullRequest, string, error)
+	AddReviewer(ctx context.Context, prID, userID string, expectedVersion *int) (*entity.PullRequest, error)
+	RemoveReviewer(ctx context.Context, prID, userID string, expectedVersion *int) (*entity.PullRequest, error)
+	SubmitReview(ctx context.Context, prID string, decision *entity.ReviewDecision) (*entity.PullRequest, error)
+	UpdatePullRequest(ctx context.Context, prID string, update *entity.PullRequestUpdate, expectedVersion *int) (*entity.PullRequest, []entity.PullRequestChange, error)
+	GetPullRequestChanges(ctx context.Context, prID string) ([]entity.PullRequestChange, error)
+	GetReviewerHistory(ctx context.Context, prID string) ([]entity.ReviewerAssignmentEvent, error)
+	MarkReady(ctx context.Context, prID string, expectedVersion *int) (*entity.PullRequest, error)
+	ClosePullRequest(ctx context.Context, prID string, expectedVersion *int) (*entity.PullRequest, error)
+	ReopenPullRequest(ctx context.Context, prID string, expectedVersion *int) (*entity.PullRequest, error)
+	GetPullRequestsNeedingReviewers(ctx context.Context) ([]entity.PullRequest, error)
 }
 
 type UserServiceInterface interface {
 	SetIsActive(ctx context.Context, userID string, isActive bool) (*entity.User, error)
+	SetExpertiseTags(ctx context.Context, userID string, tags []string) (*entity.User, error)
 	GetReviewPullRequests(ctx context.Context, userID string) ([]entity.PullRequestShort, error)
-	DeactivateTeamMembers(ctx context.Context, teamName string) ([]entity.PullRequest, error)
+	DeactivateTeamMembers(ctx context.Context, teamName string) ([]entity.ReviewerReassignment, error)
 }
 
 type TeamServiceInterface interface {
 	CreateTeam(ctx context.Context, team *entity.Team) (*entity.Team, error)
 	IsTeamExists(ctx context.Context, teamName string) (bool, error)
 	GetTeam(ctx context.Context, teamName string) (*entity.Team, error)
+	UpdateSettings(ctx context.Context, teamName string, update *entity.TeamSettingsUpdate) (*entity.TeamSettings, error)
+	SetCodeOwners(ctx context.Context, codeOwners *entity.CodeOwners) (*entity.CodeOwners, error)
+	GetCodeOwners(ctx context.Context, teamName string) (*entity.CodeOwners, error)
 }
 
 type StatisticsServiceInterface interface {
@@ -28,3 +42,7 @@ type StatisticsServiceInterface interface {
 	GetPRStats(ctx context.Context) (map[string]interface{}, error)
 	GetFullStats(ctx context.Context) (map[string]interface{}, error)
 }
+
+type AuditServiceInterface interface {
+	ListRecords(ctx context.Context, filter entity.AuditFilter) ([]entity.AuditRecord, error)
+}
@@ -1,6 +1,7 @@
 package handler
 
 import (
+	"context"
 	"errors"
 	"internship/internal/domain/entity"
 	"internship/internal/models/dto"
@@ -23,7 +24,7 @@ func NewPullRequestHandler(prService PullRequestServiceInterface, log *zap.Logge
 }
 
 // @Tags PullRequests
-// @SummaryСоздать PR и автоматически назначить до 2 ревьюверов из команды автора
+// @Summary Создать PR и автоматически назначить ревьюверов команды автора, не больше ее max_reviewers (черновик создается без ревьюверов)
 func (h *PullRequestHandler) CreatePullRequest(c *gin.Context) {
 	var req dto.CreatePRRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
@@ -34,8 +35,16 @@ func (h *PullRequestHandler) CreatePullRequest(c *gin.Context) {
 	pr := &entity.PullRequest{
 		PullRequestID:   req.PullRequestID,
 		PullRequestName: req.PullRequestName,
+		Description:     req.Description,
+		Labels:          req.Labels,
+		Metadata:        req.Metadata,
+		ChangedFiles:    req.ChangedFiles,
+		RequiredTags:    req.RequiredTags,
 		AuthorID:        req.AuthorID,
 	}
+	if req.Draft {
+		pr.Status = entity.PRStatusDraft
+	}
 	createdPR, err := h.prService.CreatePullRequest(c.Request.Context(), pr)
 	if err != nil {
 		if errors.Is(err, entity.ErrPRExists) {
@@ -48,6 +57,11 @@ func (h *PullRequestHandler) CreatePullRequest(c *gin.Context) {
 			respondError(c, http.StatusNotFound, entity.CodeNotFound, "author not found")
 			return
 		}
+		if errors.Is(err, entity.ErrInvalidInput) {
+			h.log.Error("invalid pull request fields", zap.Error(err))
+			respondError(c, http.StatusBadRequest, entity.CodeNotFound, err.Error())
+			return
+		}
 		h.log.Error("failed to create pull request", zap.Error(err))
 		respondError(c, http.StatusInternalServerError, entity.CodeNotFound, "failed to create pull request")
 		return
@@ -58,7 +72,7 @@ func (h *PullRequestHandler) CreatePullRequest(c *gin.Context) {
 }
 
 // @Tags PullRequests
-// @Summary Пометить PR как MERGED (идемпотентная операция)
+// @Summary Пометить PR как MERGED, если выполнены условия политики merge (идемпотентная операция)
 func (h *PullRequestHandler) MergePullRequest(c *gin.Context) {
 	var req dto.MergePRRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
@@ -67,13 +81,34 @@ func (h *PullRequestHandler) MergePullRequest(c *gin.Context) {
 		return
 	}
 
-	pr, err := h.prService.MergePullRequest(c.Request.Context(), req.PullRequestID)
+	pr, err := h.prService.MergePullRequest(c.Request.Context(), req.PullRequestID, req.Version)
 	if err != nil {
 		if errors.Is(err, entity.ErrPRNotFound) {
 			h.log.Error("pull request not found", zap.Error(err))
 			respondError(c, http.StatusNotFound, entity.CodeNotFound, "pull request not found")
 			return
 		}
+		if errors.Is(err, entity.ErrVersionConflict) {
+			h.log.Error("pull request version conflict", zap.Error(err))
+			respondError(c, http.StatusConflict, entity.CodeConflict, err.Error())
+			return
+		}
+		if errors.Is(err, entity.ErrPRClosed) {
+			h.log.Error("pull request closed", zap.Error(err))
+			respondError(c, http.StatusConflict, entity.CodePRClosed, err.Error())
+			return
+		}
+		if errors.Is(err, entity.ErrPRDraft) {
+			h.log.Error("pull request is a draft", zap.Error(err))
+			respondError(c, http.StatusConflict, entity.CodePRDraft, err.Error())
+			return
+		}
+		var blocked *entity.MergeBlockedError
+		if errors.As(err, &blocked) {
+			h.log.Error("pull request merge blocked", zap.Error(err))
+			respondErrorDetails(c, http.StatusConflict, entity.CodeMergeBlocked, entity.ErrMergeBlocked.Error(), blocked.Unmet)
+			return
+		}
 		h.log.Error("failed to merge pull request", zap.Error(err))
 		respondError(c, http.StatusInternalServerError, entity.CodeNotFound, "failed to merge pull request")
 		return
@@ -84,7 +119,7 @@ func (h *PullRequestHandler) MergePullRequest(c *gin.Context) {
 }
 
 // @Tags PullRequests
-// @Summary Переназначить конкретного ревьювера на другого из его команды
+// @Summary Переназначить конкретного ревьювера на выбранного или случайного участника его команды
 func (h *PullRequestHandler) ReassignReviewer(c *gin.Context) {
 	var req dto.ReassignReviewerRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
@@ -97,36 +132,315 @@ func (h *PullRequestHandler) ReassignReviewer(c *gin.Context) {
 		c.Request.Context(),
 		req.PullRequestID,
 		req.OldUserID,
+		req.NewUserID,
+		req.Version,
 	)
 
 	if err != nil {
+		statusCode, code := reviewerErrorStatus(err)
 		h.log.Error("reassign reviewer", zap.Error(err))
+		respondError(c, statusCode, code, err.Error())
+		return
+	}
+
+	h.log.Info("reassign reviewer", zap.Any("pr", pr), zap.String("new_reviewer_id", newReviewerID))
+	c.JSON(http.StatusOK, gin.H{
+		"pr":          pr,
+		"replaced_by": newReviewerID,
+	})
+}
+
+// @Tags PullRequests
+// @Summary Назначить на PR выбранного пользователя
+func (h *PullRequestHandler) AddReviewer(c *gin.Context) {
+	h.changeReviewer(c, "add reviewer", h.prService.AddReviewer)
+}
+
+// @Tags PullRequests
+// @Summary Снять ревьювера с PR
+func (h *PullRequestHandler) RemoveReviewer(c *gin.Context) {
+	h.changeReviewer(c, "remove reviewer", h.prService.RemoveReviewer)
+}
+
+// changeReviewer выполняет назначение или снятие конкретного ревьювера и отвечает PR
+func (h *PullRequestHandler) changeReviewer(
+	c *gin.Context,
+	action string,
+	change func(ctx context.Context, prID, userID string, expectedVersion *int) (*entity.PullRequest, error),
+) {
+	var req dto.ChangeReviewerRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.log.Error("invalid request body", zap.Error(err))
+		respondError(c, http.StatusBadRequest, entity.CodeNotFound, "invalid request body")
+		return
+	}
+
+	pr, err := change(c.Request.Context(), req.PullRequestID, req.UserID, req.Version)
+	if err != nil {
+		statusCode, code := reviewerErrorStatus(err)
+		h.log.Error(action, zap.Error(err))
+		respondError(c, statusCode, code, err.Error())
+		return
+	}
+
+	h.log.Info(action, zap.String("pr_id", pr.PullRequestID), zap.String("user_id", req.UserID))
+	c.JSON(http.StatusOK, gin.H{"pr": pr})
+}
+
+// reviewerErrorStatus сопоставляет ошибку изменения состава ревьюверов со статусом и кодом ответа
+func reviewerErrorStatus(err error) (int, entity.ErrorCode) {
+	switch {
+	case errors.Is(err, entity.ErrPRNotFound), errors.Is(err, entity.ErrUserNotFound):
+		return http.StatusNotFound, entity.CodeNotFound
+	case errors.Is(err, entity.ErrPRMerged):
+		return http.StatusConflict, entity.CodePRMerged
+	case errors.Is(err, entity.ErrPRClosed):
+		return http.StatusConflict, entity.CodePRClosed
+	case errors.Is(err, entity.ErrNotAssigned):
+		return http.StatusConflict, entity.CodeNotAssigned
+	case errors.Is(err, entity.ErrAlreadyAssigned):
+		return http.StatusConflict, entity.CodeAlreadyAssigned
+	case errors.Is(err, entity.ErrAuthorReviewer):
+		return http.StatusConflict, entity.CodeAuthorReviewer
+	case errors.Is(err, entity.ErrUserInactive):
+		return http.StatusConflict, entity.CodeUserInactive
+	case errors.Is(err, entity.ErrReviewerLimit):
+		return http.StatusConflict, entity.CodeReviewerLimit
+	case errors.Is(err, entity.ErrReviewerAtCapacity):
+		return http.StatusConflict, entity.CodeReviewerAtCapacity
+	case errors.Is(err, entity.ErrTeamMismatch):
+		return http.StatusConflict, entity.CodeTeamMismatch
+	case errors.Is(err, entity.ErrNoCandidate):
+		return http.StatusConflict, entity.CodeNoCandidate
+	case errors.Is(err, entity.ErrVersionConflict):
+		return http.StatusConflict, entity.CodeConflict
+	}
+	return http.StatusInternalServerError, entity.CodeNotFound
+}
+
+// @Tags PullRequests
+// @Summary Записать решение назначенного ревьювера: APPROVED, CHANGES_REQUESTED или COMMENTED
+func (h *PullRequestHandler) SubmitReview(c *gin.Context) {
+	var req dto.SubmitReviewRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.log.Error("invalid request body", zap.Error(err))
+		respondError(c, http.StatusBadRequest, entity.CodeNotFound, "invalid request body")
+		return
+	}
+
+	decision := &entity.ReviewDecision{
+		ReviewerID: req.ReviewerID,
+		Decision:   entity.ReviewDecisionType(req.Decision),
+		Comment:    req.Comment,
+	}
+	pr, err := h.prService.SubmitReview(c.Request.Context(), req.PullRequestID, decision)
+	if err != nil {
 		statusCode := http.StatusInternalServerError
 		code := entity.CodeNotFound
+		message := "failed to submit review"
 
 		switch {
-		case errors.Is(err, entity.ErrPRNotFound), errors.Is(err, entity.ErrUserNotFound):
+		case errors.Is(err, entity.ErrInvalidInput):
+			statusCode = http.StatusBadRequest
+			message = "decision must be one of APPROVED, CHANGES_REQUESTED, COMMENTED"
+		case errors.Is(err, entity.ErrPRNotFound):
 			statusCode = http.StatusNotFound
-			code = entity.CodeNotFound
+			message = "pull request not found"
 		case errors.Is(err, entity.ErrPRMerged):
 			statusCode = http.StatusConflict
 			code = entity.CodePRMerged
+			message = err.Error()
+		case errors.Is(err, entity.ErrPRClosed):
+			statusCode = http.StatusConflict
+			code = entity.CodePRClosed
+			message = err.Error()
 		case errors.Is(err, entity.ErrNotAssigned):
 			statusCode = http.StatusConflict
 			code = entity.CodeNotAssigned
-		case errors.Is(err, entity.ErrNoCandidate):
+			message = err.Error()
+		}
+
+		h.log.Error("submit review", zap.Error(err))
+		respondError(c, statusCode, code, message)
+		return
+	}
+
+	h.log.Info("review submitted", zap.String("pr_id", pr.PullRequestID), zap.String("reviewer_id", decision.ReviewerID))
+	c.JSON(http.StatusOK, gin.H{
+		"pr":     pr,
+		"review": decision,
+	})
+}
+
+// @Tags PullRequests
+// @Summary Изменить название, описание, метки и метаданные PR; правки сохраняются в истории
+func (h *PullRequestHandler) UpdatePullRequest(c *gin.Context) {
+	var req dto.UpdatePRRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.log.Error("invalid request body", zap.Error(err))
+		respondError(c, http.StatusBadRequest, entity.CodeNotFound, "invalid request body")
+		return
+	}
+
+	update := &entity.PullRequestUpdate{
+		PullRequestName: req.PullRequestName,
+		Description:     req.Description,
+		Labels:          req.Labels,
+		Metadata:        req.Metadata,
+	}
+	pr, changes, err := h.prService.UpdatePullRequest(c.Request.Context(), c.Param("id"), update, req.Version)
+	if err != nil {
+		statusCode := http.StatusInternalServerError
+		code := entity.CodeNotFound
+		message := "failed to update pull request"
+
+		switch {
+		case errors.Is(err, entity.ErrInvalidInput):
+			statusCode = http.StatusBadRequest
+			message = err.Error()
+		case errors.Is(err, entity.ErrPRNotFound):
+			statusCode = http.StatusNotFound
+			message = "pull request not found"
+		case errors.Is(err, entity.ErrPRMerged):
 			statusCode = http.StatusConflict
-			code = entity.CodeNoCandidate
+			code = entity.CodePRMerged
+			message = err.Error()
+		case errors.Is(err, entity.ErrVersionConflict):
+			statusCode = http.StatusConflict
+			code = entity.CodeConflict
+			message = err.Error()
 		}
 
-		h.log.Error("reassign reviewer", zap.Error(err))
-		respondError(c, statusCode, code, err.Error())
+		h.log.Error("update pull request", zap.Error(err))
+		respondError(c, statusCode, code, message)
 		return
 	}
 
-	h.log.Info("reassign reviewer", zap.Any("pr", pr), zap.String("new_reviewer_id", newReviewerID))
+	h.log.Info("pull request updated", zap.String("pr_id", pr.PullRequestID), zap.Int("changes", len(changes)))
 	c.JSON(http.StatusOK, gin.H{
-		"pr":          pr,
-		"replaced_by": newReviewerID,
+		"pr":      pr,
+		"changes": changes,
 	})
 }
+
+// @Tags PullRequests
+// @Summary Получить историю правок PR с прежними значениями полей
+func (h *PullRequestHandler) GetPullRequestChanges(c *gin.Context) {
+	prID := c.Param("id")
+	changes, err := h.prService.GetPullRequestChanges(c.Request.Context(), prID)
+	if err != nil {
+		if errors.Is(err, entity.ErrPRNotFound) {
+			h.log.Error("pull request not found", zap.Error(err))
+			respondError(c, http.StatusNotFound, entity.CodeNotFound, "pull request not found")
+			return
+		}
+		h.log.Error("failed to get pull request changes", zap.Error(err))
+		respondError(c, http.StatusInternalServerError, entity.CodeNotFound, "failed to get pull request changes")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"pull_request_id": prID,
+		"changes":         changes,
+	})
+}
+
+// @Tags PullRequests
+// @Summary Получить историю назначений ревьюверов PR
+func (h *PullRequestHandler) GetReviewerHistory(c *gin.Context) {
+	prID := c.Param("id")
+	events, err := h.prService.GetReviewerHistory(c.Request.Context(), prID)
+	if err != nil {
+		if errors.Is(err, entity.ErrPRNotFound) {
+			h.log.Error("pull request not found", zap.Error(err))
+			respondError(c, http.StatusNotFound, entity.CodeNotFound, "pull request not found")
+			return
+		}
+		h.log.Error("failed to get reviewer history", zap.Error(err))
+		respondError(c, http.StatusInternalServerError, entity.CodeNotFound, "failed to get reviewer history")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"pull_request_id": prID,
+		"events":          events,
+	})
+}
+
+// @Tags PullRequests
+// @Summary Отметить черновик готовым к ревью и назначить ревьюверов (идемпотентная операция)
+func (h *PullRequestHandler) MarkReady(c *gin.Context) {
+	h.changeStatus(c, "mark ready", h.prService.MarkReady)
+}
+
+// @Tags PullRequests
+// @Summary Закрыть PR без merge (идемпотентная операция)
+func (h *PullRequestHandler) ClosePullRequest(c *gin.Context) {
+	h.changeStatus(c, "close", h.prService.ClosePullRequest)
+}
+
+// @Tags PullRequests
+// @Summary Переоткрыть закрытый PR и доназначить ревьюверов (идемпотентная операция)
+func (h *PullRequestHandler) ReopenPullRequest(c *gin.Context) {
+	h.changeStatus(c, "reopen", h.prService.ReopenPullRequest)
+}
+
+// changeStatus выполняет переход PR между статусами и отвечает PR в новом статусе
+func (h *PullRequestHandler) changeStatus(
+	c *gin.Context,
+	action string,
+	transition func(ctx context.Context, prID string, expectedVersion *int) (*entity.PullRequest, error),
+) {
+	var req dto.ChangePRStatusRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.log.Error("invalid request body", zap.Error(err))
+		respondError(c, http.StatusBadRequest, entity.CodeNotFound, "invalid request body")
+		return
+	}
+
+	pr, err := transition(c.Request.Context(), req.PullRequestID, req.Version)
+	if err != nil {
+		statusCode := http.StatusInternalServerError
+		code := entity.CodeNotFound
+		message := "failed to " + action + " pull request"
+
+		switch {
+		case errors.Is(err, entity.ErrPRNotFound):
+			statusCode = http.StatusNotFound
+			message = "pull request not found"
+		case errors.Is(err, entity.ErrPRMerged):
+			statusCode = http.StatusConflict
+			code = entity.CodePRMerged
+			message = err.Error()
+		case errors.Is(err, entity.ErrPRClosed):
+			statusCode = http.StatusConflict
+			code = entity.CodePRClosed
+			message = err.Error()
+		case errors.Is(err, entity.ErrVersionConflict):
+			statusCode = http.StatusConflict
+			code = entity.CodeConflict
+			message = err.Error()
+		}
+
+		h.log.Error(action+" pull request", zap.Error(err))
+		respondError(c, statusCode, code, message)
+		return
+	}
+
+	h.log.Info(action+" pull request", zap.String("pr_id", pr.PullRequestID), zap.String("status", string(pr.Status)))
+	c.JSON(http.StatusOK, gin.H{"pr": pr})
+}
+
+// @Tags PullRequests
+// @Summary Получить открытые PR, которым не хватает ревьюверов
+func (h *PullRequestHandler) GetNeedingReviewers(c *gin.Context) {
+	prs, err := h.prService.GetPullRequestsNeedingReviewers(c.Request.Context())
+	if err != nil {
+		h.log.Error("failed to get pull requests needing reviewers", zap.Error(err))
+		respondError(c, http.StatusInternalServerError, entity.CodeNotFound, "failed to get pull requests needing reviewers")
+		return
+	}
+
+	h.log.Info("pull requests needing reviewers", zap.Int("count", len(prs)))
+	c.JSON(http.StatusOK, gin.H{"pull_requests": prs})
+}
@@ -15,3 +15,14 @@ func respondError(c *gin.Context, statusCode int, code entity.ErrorCode, message
 		},
 	})
 }
+
+// respondErrorDetails отправляет ответ с ошибкой и подробностями в поле Details
+func respondErrorDetails(c *gin.Context, statusCode int, code entity.ErrorCode, message string, details any) {
+	c.JSON(statusCode, entity.ErrorResponse{
+		Error: entity.APIError{
+			Code:    code,
+			Message: message,
+			Details: details,
+		},
+	})
+}
@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 	"internship/internal/domain/entity"
+	"internship/internal/models/dto"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
@@ -25,7 +26,8 @@ func NewTeamHandler(teamService TeamServiceInterface, log *zap.Logger) *TeamHand
 // @Tags Teams
 // @Summary Создать команду с участниками (создаёт/обновляет пользователей)
 func (h *TeamHandler) CreateTeam(c *gin.Context) {
-	var req entity.Team
+	// Настройки ревьюверов, не переданные в запросе, берутся по умолчанию
+	req := entity.Team{ReviewerSettings: entity.DefaultReviewerSettings}
 	if err := c.ShouldBindJSON(&req); err != nil {
 		h.log.Error("invalid request body", zap.Error(err))
 		respondError(c, http.StatusBadRequest, entity.CodeNotFound, "invalid request body")
@@ -43,6 +45,19 @@ func (h *TeamHandler) CreateTeam(c *gin.Context) {
 		respondError(c, http.StatusBadRequest, entity.CodeNotFound, "team must have at least one member")
 		return
 	}
+	if req.DefaultMaxOpenReviews != nil && *req.DefaultMaxOpenReviews < 0 {
+		h.log.Error("default_max_open_reviews must not be negative")
+		respondError(c, http.StatusBadRequest, entity.CodeNotFound, "default_max_open_reviews must not be negative")
+		return
+	}
+	for _, member := range req.Members {
+		if member.MaxOpenReviews != nil && *member.MaxOpenReviews < 0 {
+			h.log.Error("max_open_reviews must not be negative", zap.String("user_id", member.UserID))
+			respondError(c, http.StatusBadRequest, entity.CodeNotFound, "max_open_reviews must not be negative")
+			return
+		}
+	}
+
 	exists, err := h.teamService.IsTeamExists(c.Request.Context(), req.TeamName)
 	if err != nil {
 		h.log.Error("failed to check team exists", zap.Error(err))
@@ -57,6 +72,11 @@ func (h *TeamHandler) CreateTeam(c *gin.Context) {
 
 	createdTeam, err := h.teamService.CreateTeam(c.Request.Context(), &req)
 	if err != nil {
+		if errors.Is(err, entity.ErrInvalidInput) {
+			h.log.Error("invalid team", zap.Error(err))
+			respondError(c, http.StatusBadRequest, entity.CodeNotFound, err.Error())
+			return
+		}
 		h.log.Error("failed to create team", zap.Error(err))
 		respondError(c, http.StatusInternalServerError, entity.CodeNotFound, "failed to create team")
 		return
@@ -90,3 +110,100 @@ func (h *TeamHandler) GetTeam(c *gin.Context) {
 	h.log.Info("team", zap.Any("team", team))
 	c.JSON(http.StatusOK, gin.H{"team": team})
 }
+
+// @Tags Teams
+// @Summary Изменить число ревьюверов и резервные команды для PR авторов команды
+func (h *TeamHandler) UpdateSettings(c *gin.Context) {
+	var req dto.TeamSettingsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.log.Error("invalid request body", zap.Error(err))
+		respondError(c, http.StatusBadRequest, entity.CodeNotFound, "invalid request body")
+		return
+	}
+
+	settings, err := h.teamService.UpdateSettings(c.Request.Context(), req.TeamName, &entity.TeamSettingsUpdate{
+		MinReviewers:  req.MinReviewers,
+		MaxReviewers:  req.MaxReviewers,
+		FallbackTeams: req.FallbackTeams,
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, entity.ErrTeamNotFound):
+			h.log.Error("team not found", zap.String("team_name", req.TeamName))
+			respondError(c, http.StatusNotFound, entity.CodeNotFound, "team not found")
+		case errors.Is(err, entity.ErrInvalidInput):
+			h.log.Error("invalid team settings", zap.Error(err))
+			respondError(c, http.StatusBadRequest, entity.CodeNotFound, err.Error())
+		default:
+			h.log.Error("failed to update team settings", zap.Error(err))
+			respondError(c, http.StatusInternalServerError, entity.CodeNotFound, "failed to update team settings")
+		}
+		return
+	}
+
+	h.log.Info("team settings updated", zap.String("team_name", req.TeamName), zap.Any("settings", settings))
+	c.JSON(http.StatusOK, gin.H{
+		"team_name": req.TeamName,
+		"settings":  settings,
+	})
+}
+
+// @Tags Teams
+// @Summary Заменить правила владельцев кода команды
+func (h *TeamHandler) SetCodeOwners(c *gin.Context) {
+	var req dto.SetCodeOwnersRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.log.Error("invalid request body", zap.Error(err))
+		respondError(c, http.StatusBadRequest, entity.CodeNotFound, "invalid request body")
+		return
+	}
+
+	rules := make([]entity.CodeOwnerRule, 0, len(req.Rules))
+	for _, rule := range req.Rules {
+		rules = append(rules, entity.CodeOwnerRule{Pattern: rule.Pattern, Owners: rule.Owners})
+	}
+
+	codeOwners, err := h.teamService.SetCodeOwners(c.Request.Context(), &entity.CodeOwners{TeamName: req.TeamName, Rules: rules})
+	if err != nil {
+		switch {
+		case errors.Is(err, entity.ErrTeamNotFound):
+			h.log.Error("team not found", zap.String("team_name", req.TeamName))
+			respondError(c, http.StatusNotFound, entity.CodeNotFound, "team not found")
+		case errors.Is(err, entity.ErrInvalidInput):
+			h.log.Error("invalid code owner rules", zap.Error(err))
+			respondError(c, http.StatusBadRequest, entity.CodeNotFound, err.Error())
+		default:
+			h.log.Error("failed to set code owners", zap.Error(err))
+			respondError(c, http.StatusInternalServerError, entity.CodeNotFound, "failed to set code owners")
+		}
+		return
+	}
+
+	h.log.Info("code owners set", zap.String("team_name", req.TeamName), zap.Int("rules", len(codeOwners.Rules)))
+	c.JSON(http.StatusOK, gin.H{"code_owners": codeOwners})
+}
+
+// @Tags Teams
+// @Summary Получить правила владельцев кода команды
+func (h *TeamHandler) GetCodeOwners(c *gin.Context) {
+	teamName := c.Query("team_name")
+	if teamName == "" {
+		h.log.Error("team_name query parameter is required")
+		respondError(c, http.StatusBadRequest, entity.CodeNotFound, "team_name query parameter is required")
+		return
+	}
+
+	codeOwners, err := h.teamService.GetCodeOwners(c.Request.Context(), teamName)
+	if err != nil {
+		if errors.Is(err, entity.ErrTeamNotFound) {
+			h.log.Error("team not found")
+			respondError(c, http.StatusNotFound, entity.CodeNotFound, "team not found")
+			return
+		}
+		h.log.Error("failed to get code owners", zap.Error(err))
+		respondError(c, http.StatusInternalServerError, entity.CodeNotFound, "failed to get code owners")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"code_owners": codeOwners})
+}
@@ -48,6 +48,36 @@ func (h *UserHandler) SetIsActive(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{"user": user})
 }
 
+// @Tags Users
+// @Summary Заменить теги экспертизы пользователя
+func (h *UserHandler) SetExpertise(c *gin.Context) {
+	var req dto.SetExpertiseRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.log.Error("invalid request body", zap.Error(err))
+		respondError(c, http.StatusBadRequest, entity.CodeNotFound, "invalid request body")
+		return
+	}
+
+	user, err := h.userService.SetExpertiseTags(c.Request.Context(), req.UserID, req.ExpertiseTags)
+	if err != nil {
+		switch {
+		case errors.Is(err, entity.ErrUserNotFound):
+			h.log.Error("user not found", zap.Error(err))
+			respondError(c, http.StatusNotFound, entity.CodeNotFound, "user not found")
+		case errors.Is(err, entity.ErrInvalidInput):
+			h.log.Error("invalid expertise tags", zap.Error(err))
+			respondError(c, http.StatusBadRequest, entity.CodeNotFound, err.Error())
+		default:
+			h.log.Error("failed to update user", zap.Error(err))
+			respondError(c, http.StatusInternalServerError, entity.CodeNotFound, "failed to update user")
+		}
+		return
+	}
+
+	h.log.Info("user expertise updated", zap.Any("user", user))
+	c.JSON(http.StatusOK, gin.H{"user": user})
+}
+
 // @Tags Users
 // @Summary Получить PR'ы, где пользователь назначен ревьювером
 func (h *UserHandler) GetReview(c *gin.Context) {
@@ -89,6 +119,11 @@ func (h *UserHandler) DeactivateTeam(c *gin.Context) {
 
 	affectedPRs, err := h.userService.DeactivateTeamMembers(c.Request.Context(), req.TeamName)
 	if err != nil {
+		if errors.Is(err, entity.ErrVersionConflict) {
+			h.log.Error("pull request modified concurrently", zap.Error(err))
+			respondError(c, http.StatusConflict, entity.CodeConflict, err.Error())
+			return
+		}
 		h.log.Error("failed to deactivate team members", zap.Error(err))
 		respondError(c, http.StatusInternalServerError, entity.CodeNotFound, "failed to deactivate team members")
 		return
@@ -70,7 +70,9 @@ func (s *Server) Shutdown() error {
 func (s *Server) setupRoutes() {
 	api := s.router.Group("/api/v1")
 
+	api.Use(middleware.RequestID())
 	api.Use(middleware.Logger(s.logger))
+	api.Use(middleware.Actor())
 
 	routes.SetupRoutes(api, s.logger, s.handlers)
 
@@ -0,0 +1,25 @@
+package middleware
+
+import (
+	"internship/internal/domain/entity"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ActorHeader — заголовок, которым клиент сообщает, от чьего имени выполняется запрос
+const ActorHeader = "X-Actor-ID"
+
+// Actor кладет инициатора запроса из заголовка X-Actor-ID в контекст запроса.
+// Запрос без заголовка выполняется от имени anonymous
+func Actor() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		actor := strings.TrimSpace(c.GetHeader(ActorHeader))
+		if actor == "" {
+			actor = entity.ActorAnonymous
+		}
+
+		c.Request = c.Request.WithContext(entity.WithActor(c.Request.Context(), actor))
+		c.Next()
+	}
+}
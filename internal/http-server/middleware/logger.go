@@ -1,6 +1,7 @@
 package middleware
 
 import (
+	"internship/internal/domain/entity"
 	"time"
 
 	"github.com/gin-gonic/gin"
@@ -25,6 +26,7 @@ func Logger(logger *zap.Logger) gin.HandlerFunc {
 			zap.Duration("latency", latency),
 			zap.String("ip", c.ClientIP()),
 			zap.String("user_agent", c.Request.UserAgent()),
+			zap.String("request_id", entity.RequestIDFromContext(c.Request.Context())),
 		)
 	}
 }
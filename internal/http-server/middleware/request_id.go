@@ -0,0 +1,30 @@
+package middleware
+
+import (
+	"crypto/rand"
+	"internship/internal/domain/entity"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RequestIDHeader — заголовок с идентификатором запроса
+const RequestIDHeader = "X-Request-ID"
+
+// maxRequestIDLength ограничивает идентификатор, переданный клиентом
+const maxRequestIDLength = 64
+
+// RequestID кладет идентификатор запроса в контекст и возвращает его в ответе.
+// Идентификатор берется из заголовка X-Request-ID, а при его отсутствии генерируется
+func RequestID() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		requestID := strings.TrimSpace(c.GetHeader(RequestIDHeader))
+		if requestID == "" || len(requestID) > maxRequestIDLength {
+			requestID = rand.Text()
+		}
+
+		c.Header(RequestIDHeader, requestID)
+		c.Request = c.Request.WithContext(entity.WithRequestID(c.Request.Context(), requestID))
+		c.Next()
+	}
+}
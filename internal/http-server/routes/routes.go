@@ -17,11 +17,15 @@ func SetupRoutes(
 	{
 		team.GET("/get", handlers.TeamHandler.GetTeam)
 		team.POST("/add", handlers.TeamHandler.CreateTeam)
+		team.POST("/settings", handlers.TeamHandler.UpdateSettings)
+		team.POST("/setCodeOwners", handlers.TeamHandler.SetCodeOwners)
+		team.GET("/getCodeOwners", handlers.TeamHandler.GetCodeOwners)
 	}
 
 	users := router.Group("/users")
 	{
 		users.POST("/setIsActive", handlers.UserHandler.SetIsActive)
+		users.POST("/setExpertise", handlers.UserHandler.SetExpertise)
 		users.GET("/getReview", handlers.UserHandler.GetReview)
 		users.POST("/deactivateTeam", handlers.UserHandler.DeactivateTeam)
 	}
@@ -31,6 +35,16 @@ func SetupRoutes(
 		pullRequests.POST("/create", handlers.PullRequestHandler.CreatePullRequest)
 		pullRequests.POST("/merge", handlers.PullRequestHandler.MergePullRequest)
 		pullRequests.POST("/reassign", handlers.PullRequestHandler.ReassignReviewer)
+		pullRequests.POST("/addReviewer", handlers.PullRequestHandler.AddReviewer)
+		pullRequests.POST("/removeReviewer", handlers.PullRequestHandler.RemoveReviewer)
+		pullRequests.POST("/review", handlers.PullRequestHandler.SubmitReview)
+		pullRequests.POST("/ready", handlers.PullRequestHandler.MarkReady)
+		pullRequests.POST("/close", handlers.PullRequestHandler.ClosePullRequest)
+		pullRequests.POST("/reopen", handlers.PullRequestHandler.ReopenPullRequest)
+		pullRequests.PATCH("/:id", handlers.PullRequestHandler.UpdatePullRequest)
+		pullRequests.GET("/:id/changes", handlers.PullRequestHandler.GetPullRequestChanges)
+		pullRequests.GET("/:id/history", handlers.PullRequestHandler.GetReviewerHistory)
+		pullRequests.GET("/needReviewers", handlers.PullRequestHandler.GetNeedingReviewers)
 	}
 
 	statistics := router.Group("/statistics")
@@ -38,4 +52,6 @@ func SetupRoutes(
 		statistics.GET("", handlers.StatisticsHandler.GetStatistics)
 	}
 
+	router.GET("/audit", handlers.AuditHandler.GetAuditRecords)
+
 }
@@ -1,21 +1,103 @@
 package dto
 
+import "time"
+
+// TeamSettingsRequest — изменение настроек ревьюверов команды, отсутствующие поля не меняются.
+// Пустой fallback_teams удаляет резервные команды
+type TeamSettingsRequest struct {
+	TeamName      string   `json:"team_name" binding:"required"`
+	MinReviewers  *int     `json:"min_reviewers"`
+	MaxReviewers  *int     `json:"max_reviewers"`
+	FallbackTeams []string `json:"fallback_teams"`
+}
+
+// SetCodeOwnersRequest — замена правил владельцев кода команды; пустой rules удаляет правила
+type SetCodeOwnersRequest struct {
+	TeamName string                 `json:"team_name" binding:"required"`
+	Rules    []CodeOwnerRuleRequest `json:"rules"`
+}
+
+// CodeOwnerRuleRequest — правило владельцев кода: шаблон путей и ID пользователей-владельцев
+type CodeOwnerRuleRequest struct {
+	Pattern string   `json:"pattern" binding:"required"`
+	Owners  []string `json:"owners"`
+}
+
 type SetIsActiveRequest struct {
 	UserID   string `json:"user_id" binding:"required"`
 	IsActive bool   `json:"is_active"`
 }
 
+// SetExpertiseRequest — замена тегов экспертизы пользователя; пустой expertise_tags удаляет теги
+type SetExpertiseRequest struct {
+	UserID        string   `json:"user_id" binding:"required"`
+	ExpertiseTags []string `json:"expertise_tags"`
+}
+
 type CreatePRRequest struct {
-	PullRequestID   string `json:"pull_request_id" binding:"required"`
-	PullRequestName string `json:"pull_request_name" binding:"required"`
-	AuthorID        string `json:"author_id" binding:"required"`
+	PullRequestID   string            `json:"pull_request_id" binding:"required"`
+	PullRequestName string            `json:"pull_request_name" binding:"required"`
+	AuthorID        string            `json:"author_id" binding:"required"`
+	Draft           bool              `json:"draft"`
+	Description     string            `json:"description"`
+	Labels          []string          `json:"labels"`
+	Metadata        map[string]string `json:"metadata"`
+	// ChangedFiles — пути измененных файлов для выбора владельцев кода в ревьюверы
+	ChangedFiles []string `json:"changed_files"`
+	// RequiredTags — теги экспертизы, по совпадению с которыми предпочитаются ревьюверы
+	RequiredTags []string `json:"required_tags"`
 }
 
 type MergePRRequest struct {
 	PullRequestID string `json:"pull_request_id" binding:"required"`
+	Version       *int   `json:"version"`
+}
+
+// UpdatePRRequest — правка PR: отсутствующие поля не меняются
+type UpdatePRRequest struct {
+	PullRequestName *string           `json:"pull_request_name"`
+	Description     *string           `json:"description"`
+	Labels          []string          `json:"labels"`
+	Metadata        map[string]string `json:"metadata"`
+	Version         *int              `json:"version"`
+}
+
+type ChangePRStatusRequest struct {
+	PullRequestID string `json:"pull_request_id" binding:"required"`
+	Version       *int   `json:"version"`
 }
 
 type ReassignReviewerRequest struct {
 	PullRequestID string `json:"pull_request_id" binding:"required"`
 	OldUserID     string `json:"old_user_id" binding:"required"`
+	// NewUserID — выбранная замена из команды заменяемого ревьювера; если не задан, замена выбирается стратегией
+	NewUserID string `json:"new_user_id"`
+	Version   *int   `json:"version"`
+}
+
+// ChangeReviewerRequest — назначение или снятие конкретного ревьювера
+type ChangeReviewerRequest struct {
+	PullRequestID string `json:"pull_request_id" binding:"required"`
+	UserID        string `json:"user_id" binding:"required"`
+	Version       *int   `json:"version"`
+}
+
+type SubmitReviewRequest struct {
+	PullRequestID string `json:"pull_request_id" binding:"required"`
+	ReviewerID    string `json:"reviewer_id" binding:"required"`
+	Decision      string `json:"decision" binding:"required"`
+	Comment       string `json:"comment"`
+}
+
+// AuditQuery — фильтр журнала аудита из параметров запроса; время передается в RFC 3339
+type AuditQuery struct {
+	Actor      string     `form:"actor"`
+	RequestID  string     `form:"request_id"`
+	Action     string     `form:"action"`
+	EntityType string     `form:"entity_type"`
+	EntityID   string     `form:"entity_id"`
+	From       *time.Time `form:"from" time_format:"2006-01-02T15:04:05Z07:00"`
+	To         *time.Time `form:"to" time_format:"2006-01-02T15:04:05Z07:00"`
+	BeforeID   int64      `form:"before_id"`
+	Limit      int        `form:"limit"`
 }
@@ -0,0 +1,44 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"internship/internal/domain/entity"
+	"time"
+)
+
+type AssignmentEventRepository struct {
+	storage *Storage
+}
+
+func NewAssignmentEventRepository(storage *Storage) *AssignmentEventRepository {
+	return &AssignmentEventRepository{storage: storage}
+}
+
+// AddEvents дописывает события назначения ревьюверов в историю PR
+func (r *AssignmentEventRepository) AddEvents(ctx context.Context, events []entity.ReviewerAssignmentEvent) error {
+	defer r.storage.lock(ctx)()
+
+	for _, event := range events {
+		if _, ok := r.storage.pullRequests[event.PullRequestID]; !ok {
+			return fmt.Errorf("add reviewer assignment events: %w", entity.ErrPRNotFound)
+		}
+	}
+
+	createdAt := time.Now()
+	for i := range events {
+		events[i].CreatedAt = createdAt
+		prID := events[i].PullRequestID
+		rememberSlice(r.storage, r.storage.assignmentEvents, prID)
+		r.storage.assignmentEvents[prID] = append(r.storage.assignmentEvents[prID], events[i])
+	}
+
+	return nil
+}
+
+// GetEvents получает историю назначений ревьюверов PR в порядке записи
+func (r *AssignmentEventRepository) GetEvents(ctx context.Context, prID string) ([]entity.ReviewerAssignmentEvent, error) {
+	defer r.storage.lock(ctx)()
+
+	return append([]entity.ReviewerAssignmentEvent{}, r.storage.assignmentEvents[prID]...), nil
+}
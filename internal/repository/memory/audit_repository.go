@@ -0,0 +1,78 @@
+package memory
+
+import (
+	"context"
+	"internship/internal/domain/entity"
+	"time"
+)
+
+type AuditRepository struct {
+	storage *Storage
+}
+
+func NewAuditRepository(storage *Storage) *AuditRepository {
+	return &AuditRepository{storage: storage}
+}
+
+// AddRecords дописывает записи в журнал аудита и присваивает им ID
+func (r *AuditRepository) AddRecords(ctx context.Context, records []entity.AuditRecord) error {
+	defer r.storage.lock(ctx)()
+
+	r.storage.rememberAuditLog()
+	createdAt := time.Now()
+	for i := range records {
+		r.storage.auditSeq++
+		records[i].ID = r.storage.auditSeq
+		records[i].CreatedAt = createdAt
+		r.storage.auditLog = append(r.storage.auditLog, records[i])
+	}
+
+	return nil
+}
+
+// ListRecords получает записи журнала аудита по фильтру от новых к старым
+func (r *AuditRepository) ListRecords(ctx context.Context, filter entity.AuditFilter) ([]entity.AuditRecord, error) {
+	defer r.storage.lock(ctx)()
+
+	records := []entity.AuditRecord{}
+	for i := len(r.storage.auditLog) - 1; i >= 0 && len(records) < filter.Limit; i-- {
+		record := r.storage.auditLog[i]
+		if matchesAuditFilter(record, filter) {
+			records = append(records, record)
+		}
+	}
+
+	return records, nil
+}
+
+// DeleteBefore удаляет записи журнала аудита, созданные раньше указанного времени
+func (r *AuditRepository) DeleteBefore(ctx context.Context, before time.Time) (int64, error) {
+	defer r.storage.lock(ctx)()
+
+	r.storage.rememberAuditLog()
+	kept := make([]entity.AuditRecord, 0, len(r.storage.auditLog))
+	for _, record := range r.storage.auditLog {
+		if !record.CreatedAt.Before(before) {
+			kept = append(kept, record)
+		}
+	}
+	deleted := int64(len(r.storage.auditLog) - len(kept))
+	r.storage.auditLog = kept
+
+	return deleted, nil
+}
+
+func matchesAuditFilter(record entity.AuditRecord, filter entity.AuditFilter) bool {
+	switch {
+	case filter.Actor != "" && record.Actor != filter.Actor,
+		filter.RequestID != "" && record.RequestID != filter.RequestID,
+		filter.Action != "" && record.Action != filter.Action,
+		filter.EntityType != "" && record.EntityType != filter.EntityType,
+		filter.EntityID != "" && record.EntityID != filter.EntityID,
+		filter.From != nil && record.CreatedAt.Before(*filter.From),
+		filter.To != nil && !record.CreatedAt.Before(*filter.To),
+		filter.BeforeID != 0 && record.ID >= filter.BeforeID:
+		return false
+	}
+	return true
+}
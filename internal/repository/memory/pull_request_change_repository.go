@@ -0,0 +1,41 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"internship/internal/domain/entity"
+	"time"
+)
+
+type PullRequestChangeRepository struct {
+	storage *Storage
+}
+
+func NewPullRequestChangeRepository(storage *Storage) *PullRequestChangeRepository {
+	return &PullRequestChangeRepository{storage: storage}
+}
+
+// AddChanges сохраняет правки полей PR
+func (r *PullRequestChangeRepository) AddChanges(ctx context.Context, prID string, changes []entity.PullRequestChange) error {
+	defer r.storage.lock(ctx)()
+
+	if _, ok := r.storage.pullRequests[prID]; !ok {
+		return fmt.Errorf("add pull request changes: %w", entity.ErrPRNotFound)
+	}
+
+	changedAt := time.Now()
+	for i := range changes {
+		changes[i].ChangedAt = changedAt
+		rememberSlice(r.storage, r.storage.changes, prID)
+		r.storage.changes[prID] = append(r.storage.changes[prID], changes[i])
+	}
+
+	return nil
+}
+
+// GetChanges получает историю правок PR в порядке внесения
+func (r *PullRequestChangeRepository) GetChanges(ctx context.Context, prID string) ([]entity.PullRequestChange, error) {
+	defer r.storage.lock(ctx)()
+
+	return append([]entity.PullRequestChange{}, r.storage.changes[prID]...), nil
+}
@@ -0,0 +1,209 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"internship/internal/domain/entity"
+	"maps"
+	"sort"
+	"time"
+)
+
+type PullRequestRepository struct {
+	storage *Storage
+}
+
+func NewPullRequestRepository(storage *Storage) *PullRequestRepository {
+	return &PullRequestRepository{storage: storage}
+}
+
+// Create создает новый PR
+func (r *PullRequestRepository) Create(ctx context.Context, pr *entity.PullRequest) error {
+	defer r.storage.lock(ctx)()
+
+	if _, ok := r.storage.pullRequests[pr.PullRequestID]; ok {
+		return entity.ErrPRExists
+	}
+	if _, ok := r.storage.users[pr.AuthorID]; !ok {
+		return fmt.Errorf("create pull request: %w", entity.ErrUserNotFound)
+	}
+
+	now := time.Now()
+	stored := clonePullRequest(*pr)
+	stored.AssignedReviewers = nil
+	stored.Reviews = nil
+	stored.ShortageReason = ""
+	stored.ReviewerTeams = nil
+	stored.ReviewerRules = nil
+	stored.CreatedAt = &now
+	stored.Version = 1
+	remember(r.storage, r.storage.pullRequests, pr.PullRequestID)
+	r.storage.pullRequests[pr.PullRequestID] = stored
+	pr.Version = stored.Version
+
+	return nil
+}
+
+// GetByID получает PR по ID с ревьюверами
+func (r *PullRequestRepository) GetByID(ctx context.Context, prID string) (*entity.PullRequest, error) {
+	defer r.storage.lock(ctx)()
+
+	return r.storage.getPullRequest(prID)
+}
+
+// GetByIDForUpdate получает PR по ID с ревьюверами. Внутри транзакции хранилище уже заблокировано
+// целиком, поэтому отдельная блокировка строки не нужна
+func (r *PullRequestRepository) GetByIDForUpdate(ctx context.Context, prID string) (*entity.PullRequest, error) {
+	return r.GetByID(ctx, prID)
+}
+
+// Update обновляет PR, если его версия не изменилась с момента чтения, и увеличивает версию
+func (r *PullRequestRepository) Update(ctx context.Context, pr *entity.PullRequest) error {
+	defer r.storage.lock(ctx)()
+
+	stored, ok := r.storage.pullRequests[pr.PullRequestID]
+	if !ok {
+		return entity.ErrPRNotFound
+	}
+	if stored.Version != pr.Version {
+		return entity.ErrVersionConflict
+	}
+
+	stored.PullRequestName = pr.PullRequestName
+	stored.Description = pr.Description
+	stored.Labels = cloneLabels(pr.Labels)
+	stored.Metadata = cloneMetadata(pr.Metadata)
+	stored.Status = pr.Status
+	stored.MergedAt = cloneTime(pr.MergedAt)
+	stored.NeedMoreReviewers = pr.NeedMoreReviewers
+	stored.Version++
+	remember(r.storage, r.storage.pullRequests, pr.PullRequestID)
+	r.storage.pullRequests[pr.PullRequestID] = stored
+	pr.Version = stored.Version
+
+	return nil
+}
+
+// Exists проверяет существование PR
+func (r *PullRequestRepository) Exists(ctx context.Context, prID string) (bool, error) {
+	defer r.storage.lock(ctx)()
+
+	_, ok := r.storage.pullRequests[prID]
+	return ok, nil
+}
+
+// GetByReviewer получает PR'ы где пользователь назначен ревьювером
+func (r *PullRequestRepository) GetByReviewer(ctx context.Context, userID string) ([]entity.PullRequest, error) {
+	defer r.storage.lock(ctx)()
+
+	prs := r.storage.filterPullRequests(func(pr entity.PullRequest) bool {
+		return r.storage.isAssigned(pr.PullRequestID, userID)
+	})
+	sortByCreatedAt(prs, true)
+
+	return prs, nil
+}
+
+// GetOpenPRsByReviewers получает открытые PR'ы для списка ревьюверов
+func (r *PullRequestRepository) GetOpenPRsByReviewers(ctx context.Context, reviewerIDs []string) ([]entity.PullRequest, error) {
+	if len(reviewerIDs) == 0 {
+		return []entity.PullRequest{}, nil
+	}
+
+	defer r.storage.lock(ctx)()
+
+	prs := r.storage.filterPullRequests(func(pr entity.PullRequest) bool {
+		if pr.Status != entity.PRStatusOpen {
+			return false
+		}
+		for _, userID := range reviewerIDs {
+			if r.storage.isAssigned(pr.PullRequestID, userID) {
+				return true
+			}
+		}
+		return false
+	})
+	sortByCreatedAt(prs, true)
+
+	return prs, nil
+}
+
+// GetOpenNeedingReviewers получает открытые PR'ы, которым не хватает ревьюверов
+func (r *PullRequestRepository) GetOpenNeedingReviewers(ctx context.Context) ([]entity.PullRequest, error) {
+	defer r.storage.lock(ctx)()
+
+	prs := r.storage.filterPullRequests(func(pr entity.PullRequest) bool {
+		return pr.Status == entity.PRStatusOpen && pr.NeedMoreReviewers
+	})
+	sortByCreatedAt(prs, false)
+
+	return prs, nil
+}
+
+// getPullRequest возвращает копию PR с ревьюверами. Вызывается под блокировкой хранилища
+func (s *Storage) getPullRequest(prID string) (*entity.PullRequest, error) {
+	stored, ok := s.pullRequests[prID]
+	if !ok {
+		return nil, entity.ErrPRNotFound
+	}
+
+	pr := clonePullRequest(stored)
+	pr.AssignedReviewers = s.reviewerIDs(prID)
+	pr.Reviews = s.reviewDecisions(prID)
+
+	return &pr, nil
+}
+
+// filterPullRequests возвращает копии PR с ревьюверами, удовлетворяющих условию.
+// Вызывается под блокировкой хранилища
+func (s *Storage) filterPullRequests(match func(pr entity.PullRequest) bool) []entity.PullRequest {
+	var prs []entity.PullRequest
+	for prID, stored := range s.pullRequests {
+		if !match(stored) {
+			continue
+		}
+		pr := clonePullRequest(stored)
+		pr.AssignedReviewers = s.reviewerIDs(prID)
+		pr.Reviews = s.reviewDecisions(prID)
+		prs = append(prs, pr)
+	}
+
+	return prs
+}
+
+// sortByCreatedAt упорядочивает PR по времени создания, при равенстве — по ID
+func sortByCreatedAt(prs []entity.PullRequest, desc bool) {
+	sort.Slice(prs, func(i, j int) bool {
+		a, b := prs[i], prs[j]
+		if !a.CreatedAt.Equal(*b.CreatedAt) {
+			if desc {
+				return a.CreatedAt.After(*b.CreatedAt)
+			}
+			return a.CreatedAt.Before(*b.CreatedAt)
+		}
+		return a.PullRequestID < b.PullRequestID
+	})
+}
+
+func clonePullRequest(pr entity.PullRequest) entity.PullRequest {
+	pr.CreatedAt = cloneTime(pr.CreatedAt)
+	pr.MergedAt = cloneTime(pr.MergedAt)
+	pr.AssignedReviewers = append([]string(nil), pr.AssignedReviewers...)
+	pr.Labels = cloneLabels(pr.Labels)
+	pr.Metadata = cloneMetadata(pr.Metadata)
+	pr.ChangedFiles = cloneLabels(pr.ChangedFiles)
+	pr.RequiredTags = cloneLabels(pr.RequiredTags)
+	return pr
+}
+
+// cloneLabels копирует метки, измененные файлы или теги; отсутствующий список хранится пустым
+func cloneLabels(labels []string) []string {
+	return append([]string{}, labels...)
+}
+
+// cloneMetadata копирует метаданные PR; отсутствующие метаданные хранятся пустой картой
+func cloneMetadata(metadata map[string]string) map[string]string {
+	cloned := make(map[string]string, len(metadata))
+	maps.Copy(cloned, metadata)
+	return cloned
+}
@@ -0,0 +1,32 @@
+package memory_test
+
+import (
+	"context"
+	"internship/internal/repository/memory"
+	"internship/internal/repository/repotest"
+	"testing"
+)
+
+func newRepositories(ctx context.Context) (*repotest.Repositories, func(), error) {
+	storage := memory.NewStorage()
+	return &repotest.Repositories{
+		Team:            memory.NewTeamRepository(storage),
+		User:            memory.NewUserRepository(storage),
+		PullRequest:     memory.NewPullRequestRepository(storage),
+		Reviewer:        memory.NewReviewerRepository(storage),
+		Review:          memory.NewReviewDecisionRepository(storage),
+		Change:          memory.NewPullRequestChangeRepository(storage),
+		AssignmentEvent: memory.NewAssignmentEventRepository(storage),
+		Audit:           memory.NewAuditRepository(storage),
+		Statistics:      memory.NewStatisticsRepository(storage),
+		TxManager:       memory.NewTxManager(storage),
+	}, func() {}, nil
+}
+
+func TestRepositories(t *testing.T) {
+	repotest.Run(t, newRepositories)
+}
+
+func BenchmarkGetByReviewer(b *testing.B) {
+	repotest.BenchmarkGetByReviewer(b, newRepositories)
+}
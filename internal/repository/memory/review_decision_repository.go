@@ -0,0 +1,46 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"internship/internal/domain/entity"
+	"time"
+)
+
+type ReviewDecisionRepository struct {
+	storage *Storage
+}
+
+func NewReviewDecisionRepository(storage *Storage) *ReviewDecisionRepository {
+	return &ReviewDecisionRepository{storage: storage}
+}
+
+// AddDecision сохраняет решение ревьювера по PR
+func (r *ReviewDecisionRepository) AddDecision(ctx context.Context, prID string, decision *entity.ReviewDecision) error {
+	defer r.storage.lock(ctx)()
+
+	if _, ok := r.storage.pullRequests[prID]; !ok {
+		return fmt.Errorf("add review decision: %w", entity.ErrPRNotFound)
+	}
+	if _, ok := r.storage.users[decision.ReviewerID]; !ok {
+		return fmt.Errorf("add review decision: %w", entity.ErrUserNotFound)
+	}
+
+	decision.CreatedAt = time.Now()
+	rememberSlice(r.storage, r.storage.reviews, prID)
+	r.storage.reviews[prID] = append(r.storage.reviews[prID], *decision)
+
+	return nil
+}
+
+// GetDecisions получает решения ревьюверов по PR в порядке поступления
+func (r *ReviewDecisionRepository) GetDecisions(ctx context.Context, prID string) ([]entity.ReviewDecision, error) {
+	defer r.storage.lock(ctx)()
+
+	return r.storage.reviewDecisions(prID), nil
+}
+
+// reviewDecisions возвращает копию решений по PR. Вызывается под блокировкой хранилища
+func (s *Storage) reviewDecisions(prID string) []entity.ReviewDecision {
+	return append([]entity.ReviewDecision{}, s.reviews[prID]...)
+}
@@ -0,0 +1,128 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"internship/internal/domain/entity"
+	"slices"
+	"time"
+)
+
+type ReviewerRepository struct {
+	storage *Storage
+}
+
+func NewReviewerRepository(storage *Storage) *ReviewerRepository {
+	return &ReviewerRepository{storage: storage}
+}
+
+// AssignReviewer назначает ревьювера на PR
+func (r *ReviewerRepository) AssignReviewer(ctx context.Context, prID, userID string) error {
+	defer r.storage.lock(ctx)()
+
+	if _, ok := r.storage.pullRequests[prID]; !ok {
+		return fmt.Errorf("assign reviewer: %w", entity.ErrPRNotFound)
+	}
+	if _, ok := r.storage.users[userID]; !ok {
+		return fmt.Errorf("assign reviewer: %w", entity.ErrUserNotFound)
+	}
+	r.storage.assignReviewer(prID, userID)
+
+	return nil
+}
+
+// RemoveReviewer удаляет ревьювера с PR
+func (r *ReviewerRepository) RemoveReviewer(ctx context.Context, prID, userID string) error {
+	defer r.storage.lock(ctx)()
+
+	r.storage.removeReviewer(prID, userID)
+	return nil
+}
+
+// GetReviewers получает список ревьюверов для PR
+func (r *ReviewerRepository) GetReviewers(ctx context.Context, prID string) ([]string, error) {
+	defer r.storage.lock(ctx)()
+
+	return r.storage.reviewerIDs(prID), nil
+}
+
+// IsAssigned проверяет, назначен ли пользователь ревьювером на PR
+func (r *ReviewerRepository) IsAssigned(ctx context.Context, prID, userID string) (bool, error) {
+	defer r.storage.lock(ctx)()
+
+	return r.storage.isAssigned(prID, userID), nil
+}
+
+// ReplaceReviewer заменяет одного ревьювера на другого атомарно
+func (r *ReviewerRepository) ReplaceReviewer(ctx context.Context, prID, oldUserID, newUserID string) error {
+	defer r.storage.lock(ctx)()
+
+	if _, ok := r.storage.pullRequests[prID]; !ok {
+		return fmt.Errorf("assign new reviewer: %w", entity.ErrPRNotFound)
+	}
+	if _, ok := r.storage.users[newUserID]; !ok {
+		return fmt.Errorf("assign new reviewer: %w", entity.ErrUserNotFound)
+	}
+	r.storage.removeReviewer(prID, oldUserID)
+	r.storage.assignReviewer(prID, newUserID)
+
+	return nil
+}
+
+// CountOpenReviews возвращает число открытых PR на ревью у каждого из пользователей
+func (r *ReviewerRepository) CountOpenReviews(ctx context.Context, userIDs []string) (map[string]int, error) {
+	counts := make(map[string]int, len(userIDs))
+	if len(userIDs) == 0 {
+		return counts, nil
+	}
+
+	defer r.storage.lock(ctx)()
+
+	for prID, assignments := range r.storage.reviewers {
+		if r.storage.pullRequests[prID].Status != entity.PRStatusOpen {
+			continue
+		}
+		for _, assignment := range assignments {
+			if slices.Contains(userIDs, assignment.userID) {
+				counts[assignment.userID]++
+			}
+		}
+	}
+
+	return counts, nil
+}
+
+// assignReviewer добавляет назначение, если его еще нет. Вызывается под блокировкой хранилища
+func (s *Storage) assignReviewer(prID, userID string) {
+	if s.isAssigned(prID, userID) {
+		return
+	}
+	rememberSlice(s, s.reviewers, prID)
+	s.reviewers[prID] = append(s.reviewers[prID], reviewerAssignment{
+		userID:     userID,
+		assignedAt: time.Now(),
+	})
+}
+
+// removeReviewer удаляет назначение. Вызывается под блокировкой хранилища
+func (s *Storage) removeReviewer(prID, userID string) {
+	rememberSlice(s, s.reviewers, prID)
+	s.reviewers[prID] = slices.DeleteFunc(s.reviewers[prID], func(a reviewerAssignment) bool {
+		return a.userID == userID
+	})
+}
+
+func (s *Storage) isAssigned(prID, userID string) bool {
+	return slices.ContainsFunc(s.reviewers[prID], func(a reviewerAssignment) bool {
+		return a.userID == userID
+	})
+}
+
+// reviewerIDs возвращает ревьюверов PR в порядке назначения
+func (s *Storage) reviewerIDs(prID string) []string {
+	ids := make([]string, 0, len(s.reviewers[prID]))
+	for _, assignment := range s.reviewers[prID] {
+		ids = append(ids, assignment.userID)
+	}
+	return ids
+}
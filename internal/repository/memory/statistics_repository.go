@@ -0,0 +1,62 @@
+package memory
+
+import (
+	"context"
+	"internship/internal/domain/entity"
+)
+
+type StatisticsRepository struct {
+	storage *Storage
+}
+
+func NewStatisticsRepository(storage *Storage) *StatisticsRepository {
+	return &StatisticsRepository{storage: storage}
+}
+
+// GetAssignmentStats возвращает статистику назначений по пользователям
+func (r *StatisticsRepository) GetAssignmentStats(ctx context.Context) (map[string]int, error) {
+	defer r.storage.lock(ctx)()
+
+	counts := make(map[string]int, len(r.storage.users))
+	for _, assignments := range r.storage.reviewers {
+		for _, assignment := range assignments {
+			counts[assignment.userID]++
+		}
+	}
+
+	stats := make(map[string]int, len(r.storage.users))
+	for userID, user := range r.storage.users {
+		stats[user.Username] += counts[userID]
+	}
+
+	return stats, nil
+}
+
+// GetPRStats возвращает общую статистику по PR
+func (r *StatisticsRepository) GetPRStats(ctx context.Context) (map[string]interface{}, error) {
+	defer r.storage.lock(ctx)()
+
+	var openPRs, mergedPRs, draftPRs, closedPRs int
+	for _, pr := range r.storage.pullRequests {
+		switch pr.Status {
+		case entity.PRStatusOpen:
+			openPRs++
+		case entity.PRStatusMerged:
+			mergedPRs++
+		case entity.PRStatusDraft:
+			draftPRs++
+		case entity.PRStatusClosed:
+			closedPRs++
+		}
+	}
+
+	stats := map[string]interface{}{
+		"total_prs":  len(r.storage.pullRequests),
+		"open_prs":   openPRs,
+		"merged_prs": mergedPRs,
+		"draft_prs":  draftPRs,
+		"closed_prs": closedPRs,
+	}
+
+	return stats, nil
+}
@@ -0,0 +1,178 @@
+package memory
+
+import (
+	"context"
+	"internship/internal/domain/entity"
+	"slices"
+	"sync"
+	"time"
+)
+
+// reviewerAssignment — назначение ревьювера на PR
+type reviewerAssignment struct {
+	userID     string
+	assignedAt time.Time
+}
+
+// Storage хранит данные всех in-memory репозиториев.
+// Доступ сериализуется одним мьютексом: операция вне транзакции захватывает его на время вызова,
+// транзакция — на все время выполнения, поэтому транзакции изолированы друг от друга
+type Storage struct {
+	mu           sync.Mutex
+	teams        map[string]entity.Team
+	users        map[string]entity.User
+	pullRequests map[string]entity.PullRequest
+	// fallbackTeams хранит резервные команды каждой команды в порядке обращения к ним
+	fallbackTeams map[string][]string
+	// codeOwnerRules хранит правила владельцев кода каждой команды; список правил заменяется целиком
+	codeOwnerRules map[string][]entity.CodeOwnerRule
+	// reviewers хранит назначения каждого PR в порядке assigned_at
+	reviewers map[string][]reviewerAssignment
+	// reviews хранит решения ревьюверов каждого PR в порядке поступления
+	reviews map[string][]entity.ReviewDecision
+	// changes хранит историю правок каждого PR в порядке внесения
+	changes map[string][]entity.PullRequestChange
+	// assignmentEvents хранит историю назначений ревьюверов каждого PR в порядке записи
+	assignmentEvents map[string][]entity.ReviewerAssignmentEvent
+	// auditLog хранит журнал аудита в порядке записи, auditSeq — последний выданный ID записи
+	auditLog []entity.AuditRecord
+	auditSeq int64
+	// undo хранит в порядке изменений операции отката текущей транзакции; recording включен на время транзакции
+	undo      []func()
+	recording bool
+}
+
+func NewStorage() *Storage {
+	return &Storage{
+		teams:            make(map[string]entity.Team),
+		users:            make(map[string]entity.User),
+		pullRequests:     make(map[string]entity.PullRequest),
+		fallbackTeams:    make(map[string][]string),
+		codeOwnerRules:   make(map[string][]entity.CodeOwnerRule),
+		reviewers:        make(map[string][]reviewerAssignment),
+		reviews:          make(map[string][]entity.ReviewDecision),
+		changes:          make(map[string][]entity.PullRequestChange),
+		assignmentEvents: make(map[string][]entity.ReviewerAssignmentEvent),
+	}
+}
+
+// txKey — ключ контекста, под которым хранится хранилище с открытой транзакцией
+type txKey struct{}
+
+// lock захватывает хранилище, если вызов выполняется не внутри транзакции этого хранилища
+func (s *Storage) lock(ctx context.Context) func() {
+	if s.inTx(ctx) {
+		return func() {}
+	}
+	s.mu.Lock()
+	return s.mu.Unlock
+}
+
+func (s *Storage) inTx(ctx context.Context) bool {
+	storage, ok := ctx.Value(txKey{}).(*Storage)
+	return ok && storage == s
+}
+
+// remember запоминает текущее значение ключа карты хранилища, чтобы восстановить его при откате транзакции.
+// Сущности хранятся по значению, а их указатели никогда не изменяются на месте, поэтому копировать значение не нужно.
+// Вне транзакции ничего не запоминается
+func remember[K comparable, V any](s *Storage, m map[K]V, key K) {
+	if !s.recording {
+		return
+	}
+	old, ok := m[key]
+	s.undo = append(s.undo, func() {
+		if ok {
+			m[key] = old
+		} else {
+			delete(m, key)
+		}
+	})
+}
+
+// rememberSlice запоминает копию списка по ключу карты хранилища: списки назначений изменяются на месте
+func rememberSlice[K comparable, V any](s *Storage, m map[K][]V, key K) {
+	if !s.recording {
+		return
+	}
+	old, ok := m[key]
+	old = slices.Clone(old)
+	s.undo = append(s.undo, func() {
+		if ok {
+			m[key] = old
+		} else {
+			delete(m, key)
+		}
+	})
+}
+
+// rememberAuditLog запоминает журнал аудита. Журнал только дополняется, а очистка создает новый срез,
+// поэтому для отката достаточно сохранить заголовок среза без копирования записей
+func (s *Storage) rememberAuditLog() {
+	if !s.recording {
+		return
+	}
+	auditLog, auditSeq := s.auditLog, s.auditSeq
+	s.undo = append(s.undo, func() {
+		s.auditLog, s.auditSeq = auditLog, auditSeq
+	})
+}
+
+// rollback отменяет изменения транзакции в обратном порядке
+func (s *Storage) rollback() {
+	for i := len(s.undo) - 1; i >= 0; i-- {
+		s.undo[i]()
+	}
+}
+
+// TxManager выполняет операции нескольких in-memory репозиториев атомарно
+type TxManager struct {
+	storage *Storage
+}
+
+func NewTxManager(storage *Storage) *TxManager {
+	return &TxManager{storage: storage}
+}
+
+// WithinTransaction выполняет fn под блокировкой хранилища и откатывает все изменения при ошибке.
+// Откат использует журнал отмены затронутых ключей, поэтому стоимость транзакции не зависит от объема хранилища.
+// Если в контексте уже есть транзакция, fn выполняется в ней
+func (m *TxManager) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	if m.storage.inTx(ctx) {
+		return fn(ctx)
+	}
+
+	m.storage.mu.Lock()
+	defer m.storage.mu.Unlock()
+
+	m.storage.recording = true
+	defer func() {
+		m.storage.undo = nil
+		m.storage.recording = false
+	}()
+
+	if err := fn(context.WithValue(ctx, txKey{}, m.storage)); err != nil {
+		m.storage.rollback()
+		return err
+	}
+
+	return nil
+}
+
+// cloneInt копирует необязательное число, чтобы вызывающий код не изменял данные хранилища
+func cloneInt(v *int) *int {
+	if v == nil {
+		return nil
+	}
+	c := *v
+	return &c
+}
+
+// cloneTime копирует необязательное время
+func cloneTime(v *time.Time) *time.Time {
+	if v == nil {
+		return nil
+	}
+	c := *v
+	return &c
+}
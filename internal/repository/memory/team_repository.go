@@ -0,0 +1,123 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"internship/internal/domain/entity"
+	"slices"
+)
+
+type TeamRepository struct {
+	storage *Storage
+}
+
+func NewTeamRepository(storage *Storage) *TeamRepository {
+	return &TeamRepository{storage: storage}
+}
+
+// Create создает новую команду
+func (r *TeamRepository) Create(ctx context.Context, team *entity.Team) error {
+	defer r.storage.lock(ctx)()
+
+	if _, ok := r.storage.teams[team.TeamName]; ok {
+		return fmt.Errorf("create team: %w", entity.ErrTeamExists)
+	}
+
+	remember(r.storage, r.storage.teams, team.TeamName)
+	r.storage.teams[team.TeamName] = entity.Team{
+		TeamName:              team.TeamName,
+		DefaultMaxOpenReviews: cloneInt(team.DefaultMaxOpenReviews),
+		ReviewerSettings:      team.ReviewerSettings,
+	}
+
+	return nil
+}
+
+// GetByName получает команду по имени
+func (r *TeamRepository) GetByName(ctx context.Context, teamName string) (*entity.Team, error) {
+	defer r.storage.lock(ctx)()
+
+	team, ok := r.storage.teams[teamName]
+	if !ok {
+		return nil, entity.ErrTeamNotFound
+	}
+	team.DefaultMaxOpenReviews = cloneInt(team.DefaultMaxOpenReviews)
+
+	return &team, nil
+}
+
+// Exists проверяет существование команды
+func (r *TeamRepository) Exists(ctx context.Context, teamName string) (bool, error) {
+	defer r.storage.lock(ctx)()
+
+	_, ok := r.storage.teams[teamName]
+	return ok, nil
+}
+
+// UpdateReviewerSettings сохраняет настройки числа ревьюверов команды
+func (r *TeamRepository) UpdateReviewerSettings(ctx context.Context, teamName string, settings entity.ReviewerSettings) error {
+	defer r.storage.lock(ctx)()
+
+	team, ok := r.storage.teams[teamName]
+	if !ok {
+		return entity.ErrTeamNotFound
+	}
+	team.ReviewerSettings = settings
+	remember(r.storage, r.storage.teams, teamName)
+	r.storage.teams[teamName] = team
+
+	return nil
+}
+
+// SetFallbackTeams заменяет резервные команды команды, сохраняя их порядок
+func (r *TeamRepository) SetFallbackTeams(ctx context.Context, teamName string, fallbackTeams []string) error {
+	defer r.storage.lock(ctx)()
+
+	if _, ok := r.storage.teams[teamName]; !ok {
+		return fmt.Errorf("set fallback teams: %w", entity.ErrTeamNotFound)
+	}
+	for _, fallbackTeam := range fallbackTeams {
+		if _, ok := r.storage.teams[fallbackTeam]; !ok {
+			return fmt.Errorf("set fallback teams: %w", entity.ErrTeamNotFound)
+		}
+	}
+	remember(r.storage, r.storage.fallbackTeams, teamName)
+	r.storage.fallbackTeams[teamName] = slices.Clone(fallbackTeams)
+
+	return nil
+}
+
+// GetFallbackTeams получает резервные команды команды по порядку
+func (r *TeamRepository) GetFallbackTeams(ctx context.Context, teamName string) ([]string, error) {
+	defer r.storage.lock(ctx)()
+
+	return append([]string{}, r.storage.fallbackTeams[teamName]...), nil
+}
+
+// SetCodeOwnerRules заменяет правила владельцев кода команды, сохраняя их порядок
+func (r *TeamRepository) SetCodeOwnerRules(ctx context.Context, teamName string, rules []entity.CodeOwnerRule) error {
+	defer r.storage.lock(ctx)()
+
+	if _, ok := r.storage.teams[teamName]; !ok {
+		return fmt.Errorf("set code owner rules: %w", entity.ErrTeamNotFound)
+	}
+	remember(r.storage, r.storage.codeOwnerRules, teamName)
+	r.storage.codeOwnerRules[teamName] = cloneCodeOwnerRules(rules)
+
+	return nil
+}
+
+// GetCodeOwnerRules получает правила владельцев кода команды по порядку
+func (r *TeamRepository) GetCodeOwnerRules(ctx context.Context, teamName string) ([]entity.CodeOwnerRule, error) {
+	defer r.storage.lock(ctx)()
+
+	return cloneCodeOwnerRules(r.storage.codeOwnerRules[teamName]), nil
+}
+
+func cloneCodeOwnerRules(rules []entity.CodeOwnerRule) []entity.CodeOwnerRule {
+	cloned := make([]entity.CodeOwnerRule, 0, len(rules))
+	for _, rule := range rules {
+		cloned = append(cloned, entity.CodeOwnerRule{Pattern: rule.Pattern, Owners: slices.Clone(rule.Owners)})
+	}
+	return cloned
+}
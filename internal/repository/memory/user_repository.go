@@ -0,0 +1,158 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"internship/internal/domain/entity"
+	"sort"
+)
+
+type UserRepository struct {
+	storage *Storage
+}
+
+func NewUserRepository(storage *Storage) *UserRepository {
+	return &UserRepository{storage: storage}
+}
+
+// BatchCreateOrUpdate создает или обновляет пользователей атомарно
+func (r *UserRepository) BatchCreateOrUpdate(ctx context.Context, users []*entity.User) error {
+	defer r.storage.lock(ctx)()
+
+	for _, user := range users {
+		if _, ok := r.storage.teams[user.TeamName]; !ok {
+			return fmt.Errorf("update user %s: %w", user.UserID, entity.ErrTeamNotFound)
+		}
+	}
+
+	for _, user := range users {
+		remember(r.storage, r.storage.users, user.UserID)
+		r.storage.users[user.UserID] = cloneUser(*user)
+	}
+
+	return nil
+}
+
+// Update обновляет данные пользователя
+func (r *UserRepository) Update(ctx context.Context, user *entity.User) error {
+	defer r.storage.lock(ctx)()
+
+	if _, ok := r.storage.users[user.UserID]; !ok {
+		return entity.ErrUserNotFound
+	}
+	if _, ok := r.storage.teams[user.TeamName]; !ok {
+		return fmt.Errorf("update user: %w", entity.ErrTeamNotFound)
+	}
+
+	remember(r.storage, r.storage.users, user.UserID)
+	r.storage.users[user.UserID] = cloneUser(*user)
+	return nil
+}
+
+// GetByID получает пользователя по ID
+func (r *UserRepository) GetByID(ctx context.Context, userID string) (*entity.User, error) {
+	defer r.storage.lock(ctx)()
+
+	user, ok := r.storage.users[userID]
+	if !ok {
+		return nil, entity.ErrUserNotFound
+	}
+	user = cloneUser(user)
+
+	return &user, nil
+}
+
+// GetByTeamName получает всех пользователей команды в порядке user_id
+func (r *UserRepository) GetByTeamName(ctx context.Context, teamName string) ([]entity.User, error) {
+	defer r.storage.lock(ctx)()
+
+	var users []entity.User
+	for _, user := range r.storage.users {
+		if user.TeamName == teamName {
+			users = append(users, cloneUser(user))
+		}
+	}
+	sort.Slice(users, func(i, j int) bool {
+		return users[i].UserID < users[j].UserID
+	})
+
+	return users, nil
+}
+
+// SetIsActive устанавливает флаг активности пользователя
+func (r *UserRepository) SetIsActive(ctx context.Context, userID string, isActive bool) error {
+	defer r.storage.lock(ctx)()
+
+	user, ok := r.storage.users[userID]
+	if !ok {
+		return entity.ErrUserNotFound
+	}
+	user.IsActive = isActive
+	remember(r.storage, r.storage.users, userID)
+	r.storage.users[userID] = user
+
+	return nil
+}
+
+// SetExpertiseTags заменяет теги экспертизы пользователя
+func (r *UserRepository) SetExpertiseTags(ctx context.Context, userID string, tags []string) error {
+	defer r.storage.lock(ctx)()
+
+	user, ok := r.storage.users[userID]
+	if !ok {
+		return entity.ErrUserNotFound
+	}
+	user.ExpertiseTags = cloneLabels(tags)
+	remember(r.storage, r.storage.users, userID)
+	r.storage.users[userID] = user
+
+	return nil
+}
+
+// DeactivateTeamMembers деактивирует всех участников команды и применяет замены ревьюверов
+// открытых PR атомарно
+func (r *UserRepository) DeactivateTeamMembers(ctx context.Context, teamName string, reassignments []entity.ReviewerReassignment) error {
+	defer r.storage.lock(ctx)()
+
+	// Проверяем версии до изменений, чтобы при конфликте ничего не применить
+	for _, reassignment := range reassignments {
+		pr, ok := r.storage.pullRequests[reassignment.PullRequest.PullRequestID]
+		if !ok || pr.Version != reassignment.PullRequest.Version {
+			return fmt.Errorf("update pr %s: %w", reassignment.PullRequest.PullRequestID, entity.ErrVersionConflict)
+		}
+	}
+
+	for userID, user := range r.storage.users {
+		if user.TeamName == teamName {
+			user.IsActive = false
+			remember(r.storage, r.storage.users, userID)
+			r.storage.users[userID] = user
+		}
+	}
+
+	for i := range reassignments {
+		reassignment := &reassignments[i]
+		prID := reassignment.PullRequest.PullRequestID
+		for _, userID := range reassignment.RemovedReviewers {
+			r.storage.removeReviewer(prID, userID)
+		}
+		for _, userID := range reassignment.AddedReviewers {
+			r.storage.assignReviewer(prID, userID)
+		}
+
+		pr := r.storage.pullRequests[prID]
+		pr.NeedMoreReviewers = reassignment.PullRequest.NeedMoreReviewers
+		pr.Version++
+		remember(r.storage, r.storage.pullRequests, prID)
+		r.storage.pullRequests[prID] = pr
+		reassignment.PullRequest.Version = pr.Version
+	}
+
+	return nil
+}
+
+func cloneUser(user entity.User) entity.User {
+	user.MaxOpenReviews = cloneInt(user.MaxOpenReviews)
+	user.ExpertiseTags = cloneLabels(user.ExpertiseTags)
+	return user
+}
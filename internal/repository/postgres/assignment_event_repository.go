@@ -0,0 +1,93 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"internship/internal/domain/entity"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+const (
+	queryAddAssignmentEvent = `
+		INSERT INTO reviewer_assignment_events (pull_request_id, event_type, user_id, previous_user_id, reason, actor, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+	`
+	queryGetAssignmentEvents = `
+		SELECT pull_request_id, event_type, user_id, previous_user_id, reason, actor, created_at
+		FROM reviewer_assignment_events
+		WHERE pull_request_id = $1
+		ORDER BY id
+	`
+)
+
+type AssignmentEventRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewAssignmentEventRepository(pool *pgxpool.Pool) *AssignmentEventRepository {
+	return &AssignmentEventRepository{pool: pool}
+}
+
+// AddEvents дописывает события назначения ревьюверов в историю PR одним батчем
+func (r *AssignmentEventRepository) AddEvents(ctx context.Context, events []entity.ReviewerAssignmentEvent) error {
+	if len(events) == 0 {
+		return nil
+	}
+
+	createdAt := time.Now()
+	batch := &pgx.Batch{}
+	for i := range events {
+		events[i].CreatedAt = createdAt
+		batch.Queue(queryAddAssignmentEvent,
+			events[i].PullRequestID,
+			events[i].Event,
+			events[i].UserID,
+			events[i].PreviousUserID,
+			events[i].Reason,
+			events[i].Actor,
+			createdAt,
+		)
+	}
+
+	if err := conn(ctx, r.pool).SendBatch(ctx, batch).Close(); err != nil {
+		return fmt.Errorf("add reviewer assignment events: %w", err)
+	}
+
+	return nil
+}
+
+// GetEvents получает историю назначений ревьюверов PR в порядке записи
+func (r *AssignmentEventRepository) GetEvents(ctx context.Context, prID string) ([]entity.ReviewerAssignmentEvent, error) {
+	rows, err := conn(ctx, r.pool).Query(ctx, queryGetAssignmentEvents, prID)
+	if err != nil {
+		return nil, fmt.Errorf("get reviewer assignment events: %w", err)
+	}
+	defer rows.Close()
+
+	events := []entity.ReviewerAssignmentEvent{}
+	for rows.Next() {
+		var event entity.ReviewerAssignmentEvent
+		err := rows.Scan(
+			&event.PullRequestID,
+			&event.Event,
+			&event.UserID,
+			&event.PreviousUserID,
+			&event.Reason,
+			&event.Actor,
+			&event.CreatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("scan reviewer assignment event: %w", err)
+		}
+		events = append(events, event)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate reviewer assignment events: %w", err)
+	}
+
+	return events, nil
+}
@@ -0,0 +1,135 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"internship/internal/domain/entity"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+const (
+	queryAddAuditRecord = `
+		INSERT INTO audit_log (action, entity_type, entity_id, actor, request_id, before_state, after_state, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		RETURNING id
+	`
+	// Пустые параметры фильтра не ограничивают выборку
+	queryListAuditRecords = `
+		SELECT id, action, entity_type, entity_id, actor, request_id, before_state, after_state, created_at
+		FROM audit_log
+		WHERE ($1 = '' OR actor = $1)
+			AND ($2 = '' OR request_id = $2)
+			AND ($3 = '' OR action = $3)
+			AND ($4 = '' OR entity_type = $4)
+			AND ($5 = '' OR entity_id = $5)
+			AND ($6::timestamptz IS NULL OR created_at >= $6)
+			AND ($7::timestamptz IS NULL OR created_at < $7)
+			AND ($8 = 0 OR id < $8)
+		ORDER BY id DESC
+		LIMIT $9
+	`
+	queryDeleteAuditRecordsBefore = `DELETE FROM audit_log WHERE created_at < $1`
+)
+
+type AuditRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewAuditRepository(pool *pgxpool.Pool) *AuditRepository {
+	return &AuditRepository{pool: pool}
+}
+
+// AddRecords дописывает записи в журнал аудита и присваивает им ID
+func (r *AuditRepository) AddRecords(ctx context.Context, records []entity.AuditRecord) error {
+	if len(records) == 0 {
+		return nil
+	}
+
+	// Записи отправляются одним пакетом, чтобы не ждать ответа базы на каждую
+	createdAt := time.Now()
+	batch := &pgx.Batch{}
+	for i := range records {
+		batch.Queue(queryAddAuditRecord,
+			records[i].Action,
+			records[i].EntityType,
+			records[i].EntityID,
+			records[i].Actor,
+			records[i].RequestID,
+			records[i].Before,
+			records[i].After,
+			createdAt,
+		)
+	}
+
+	results := conn(ctx, r.pool).SendBatch(ctx, batch)
+	for i := range records {
+		if err := results.QueryRow().Scan(&records[i].ID); err != nil {
+			_ = results.Close()
+			return fmt.Errorf("add audit record: %w", err)
+		}
+		records[i].CreatedAt = createdAt
+	}
+	if err := results.Close(); err != nil {
+		return fmt.Errorf("add audit records: %w", err)
+	}
+
+	return nil
+}
+
+// ListRecords получает записи журнала аудита по фильтру от новых к старым
+func (r *AuditRepository) ListRecords(ctx context.Context, filter entity.AuditFilter) ([]entity.AuditRecord, error) {
+	rows, err := conn(ctx, r.pool).Query(ctx, queryListAuditRecords,
+		filter.Actor,
+		filter.RequestID,
+		string(filter.Action),
+		string(filter.EntityType),
+		filter.EntityID,
+		filter.From,
+		filter.To,
+		filter.BeforeID,
+		filter.Limit,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("list audit records: %w", err)
+	}
+	defer rows.Close()
+
+	records := []entity.AuditRecord{}
+	for rows.Next() {
+		var record entity.AuditRecord
+		err := rows.Scan(
+			&record.ID,
+			&record.Action,
+			&record.EntityType,
+			&record.EntityID,
+			&record.Actor,
+			&record.RequestID,
+			&record.Before,
+			&record.After,
+			&record.CreatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("scan audit record: %w", err)
+		}
+		records = append(records, record)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate audit records: %w", err)
+	}
+
+	return records, nil
+}
+
+// DeleteBefore удаляет записи журнала аудита, созданные раньше указанного времени
+func (r *AuditRepository) DeleteBefore(ctx context.Context, before time.Time) (int64, error) {
+	tag, err := conn(ctx, r.pool).Exec(ctx, queryDeleteAuditRecordsBefore, before)
+	if err != nil {
+		return 0, fmt.Errorf("delete audit records: %w", err)
+	}
+
+	return tag.RowsAffected(), nil
+}
@@ -0,0 +1,261 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// Таблица schema_migrations совместима с golang-migrate: база, мигрированная CLI,
+// продолжает мигрироваться сервисом и наоборот
+const (
+	queryCreateSchemaMigrations = `
+		CREATE TABLE IF NOT EXISTS schema_migrations (
+			version BIGINT NOT NULL PRIMARY KEY,
+			dirty BOOLEAN NOT NULL
+		)
+	`
+	queryGetSchemaVersion   = `SELECT version, dirty FROM schema_migrations LIMIT 1`
+	queryClearSchemaVersion = `DELETE FROM schema_migrations`
+	querySetSchemaVersion   = `INSERT INTO schema_migrations (version, dirty) VALUES ($1, false)`
+	// queryLockMigrations не дает нескольким экземплярам сервиса мигрировать базу одновременно
+	queryLockMigrations = `SELECT pg_advisory_xact_lock(7426019351)`
+)
+
+// ErrDirtyDatabase — предыдущая миграция golang-migrate завершилась с ошибкой
+var ErrDirtyDatabase = errors.New("database is dirty, fix it manually and force the version")
+
+// Migration — миграция из набора
+type Migration struct {
+	Version int
+	Name    string
+	up      string
+	down    string
+}
+
+// MigrationStatus — миграция и признак ее применения
+type MigrationStatus struct {
+	Migration
+	Applied bool
+}
+
+// Migrator применяет и откатывает встроенные миграции
+type Migrator struct {
+	pool       *pgxpool.Pool
+	migrations []Migration
+}
+
+// NewMigrator читает миграции из fsys
+func NewMigrator(pool *pgxpool.Pool, fsys fs.FS) (*Migrator, error) {
+	migrations, err := readMigrations(fsys)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Migrator{pool: pool, migrations: migrations}, nil
+}
+
+// Up применяет все недостающие миграции и возвращает примененные
+func (m *Migrator) Up(ctx context.Context) ([]Migration, error) {
+	var applied []Migration
+	for _, migration := range m.migrations {
+		ok, err := m.step(ctx, func(version int) (int, string, bool) {
+			return migration.Version, migration.up, migration.Version > version
+		})
+		if err != nil {
+			return applied, fmt.Errorf("apply migration %d_%s: %w", migration.Version, migration.Name, err)
+		}
+		if ok {
+			applied = append(applied, migration)
+		}
+	}
+
+	return applied, nil
+}
+
+// Down откатывает последние steps примененных миграций и возвращает откаченные
+func (m *Migrator) Down(ctx context.Context, steps int) ([]Migration, error) {
+	var reverted []Migration
+	for range steps {
+		var current Migration
+		ok, err := m.step(ctx, func(version int) (int, string, bool) {
+			i := m.index(version)
+			if i < 0 {
+				return 0, "", false
+			}
+			current = m.migrations[i]
+			if i == 0 {
+				return 0, current.down, true
+			}
+			return m.migrations[i-1].Version, current.down, true
+		})
+		if err != nil {
+			return reverted, fmt.Errorf("revert migration %d_%s: %w", current.Version, current.Name, err)
+		}
+		if !ok {
+			break
+		}
+		reverted = append(reverted, current)
+	}
+
+	return reverted, nil
+}
+
+// Version возвращает текущую версию схемы, 0 — миграции не применялись
+func (m *Migrator) Version(ctx context.Context) (int, bool, error) {
+	if _, err := m.pool.Exec(ctx, queryCreateSchemaMigrations); err != nil {
+		return 0, false, fmt.Errorf("create schema_migrations: %w", err)
+	}
+
+	return schemaVersion(ctx, m.pool)
+}
+
+// Status возвращает все миграции с признаком применения
+func (m *Migrator) Status(ctx context.Context) ([]MigrationStatus, error) {
+	version, _, err := m.Version(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := make([]MigrationStatus, 0, len(m.migrations))
+	for _, migration := range m.migrations {
+		statuses = append(statuses, MigrationStatus{
+			Migration: migration,
+			Applied:   migration.Version <= version,
+		})
+	}
+
+	return statuses, nil
+}
+
+// step выполняет один шаг миграции в транзакции под advisory-блокировкой.
+// plan по текущей версии возвращает новую версию, скрипт и признак необходимости шага
+func (m *Migrator) step(ctx context.Context, plan func(version int) (int, string, bool)) (bool, error) {
+	if _, err := m.pool.Exec(ctx, queryCreateSchemaMigrations); err != nil {
+		return false, fmt.Errorf("create schema_migrations: %w", err)
+	}
+
+	var done bool
+	err := runInTx(ctx, m.pool, func(ctx context.Context) error {
+		q := conn(ctx, m.pool)
+		if _, err := q.Exec(ctx, queryLockMigrations); err != nil {
+			return fmt.Errorf("lock migrations: %w", err)
+		}
+
+		// Версия читается под блокировкой: другой экземпляр мог уже выполнить шаг
+		version, dirty, err := schemaVersion(ctx, q)
+		if err != nil {
+			return err
+		}
+		if dirty {
+			return fmt.Errorf("version %d: %w", version, ErrDirtyDatabase)
+		}
+
+		newVersion, script, ok := plan(version)
+		if !ok {
+			return nil
+		}
+
+		if _, err := q.Exec(ctx, script); err != nil {
+			return err
+		}
+		if _, err := q.Exec(ctx, queryClearSchemaVersion); err != nil {
+			return fmt.Errorf("clear schema version: %w", err)
+		}
+		if newVersion > 0 {
+			if _, err := q.Exec(ctx, querySetSchemaVersion, newVersion); err != nil {
+				return fmt.Errorf("set schema version: %w", err)
+			}
+		}
+
+		done = true
+		return nil
+	})
+
+	return done, err
+}
+
+// index возвращает позицию миграции с версией version или -1
+func (m *Migrator) index(version int) int {
+	for i, migration := range m.migrations {
+		if migration.Version == version {
+			return i
+		}
+	}
+	return -1
+}
+
+func schemaVersion(ctx context.Context, q querier) (int, bool, error) {
+	var version int
+	var dirty bool
+	err := q.QueryRow(ctx, queryGetSchemaVersion).Scan(&version, &dirty)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return 0, false, nil
+		}
+		return 0, false, fmt.Errorf("get schema version: %w", err)
+	}
+
+	return version, dirty, nil
+}
+
+// readMigrations читает пары up/down миграций, упорядоченные по версии
+func readMigrations(fsys fs.FS) ([]Migration, error) {
+	files, err := fs.Glob(fsys, "*.sql")
+	if err != nil {
+		return nil, fmt.Errorf("list migrations: %w", err)
+	}
+
+	byVersion := make(map[int]*Migration)
+	for _, file := range files {
+		prefix, rest, ok := strings.Cut(file, "_")
+		if !ok {
+			return nil, fmt.Errorf("invalid migration file name %s", file)
+		}
+		version, err := strconv.Atoi(prefix)
+		if err != nil {
+			return nil, fmt.Errorf("parse migration version %s: %w", file, err)
+		}
+
+		script, err := fs.ReadFile(fsys, file)
+		if err != nil {
+			return nil, fmt.Errorf("read migration %s: %w", file, err)
+		}
+
+		migration, ok := byVersion[version]
+		if !ok {
+			migration = &Migration{Version: version}
+			byVersion[version] = migration
+		}
+
+		switch {
+		case strings.HasSuffix(rest, ".up.sql"):
+			migration.Name = strings.TrimSuffix(rest, ".up.sql")
+			migration.up = string(script)
+		case strings.HasSuffix(rest, ".down.sql"):
+			migration.down = string(script)
+		default:
+			return nil, fmt.Errorf("invalid migration file name %s", file)
+		}
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, migration := range byVersion {
+		if migration.up == "" {
+			return nil, fmt.Errorf("migration %d has no up script", migration.Version)
+		}
+		migrations = append(migrations, *migration)
+	}
+	sort.Slice(migrations, func(i, j int) bool {
+		return migrations[i].Version < migrations[j].Version
+	})
+
+	return migrations, nil
+}
@@ -0,0 +1,82 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"internship/internal/domain/entity"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+const (
+	queryAddChange = `
+		INSERT INTO pull_request_changes (pull_request_id, field, old_value, new_value, changed_at)
+		VALUES ($1, $2, $3, $4, $5)
+	`
+	queryGetChanges = `
+		SELECT field, old_value, new_value, changed_at
+		FROM pull_request_changes
+		WHERE pull_request_id = $1
+		ORDER BY id
+	`
+)
+
+type PullRequestChangeRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewPullRequestChangeRepository(pool *pgxpool.Pool) *PullRequestChangeRepository {
+	return &PullRequestChangeRepository{pool: pool}
+}
+
+// AddChanges сохраняет правки полей PR одним батчем
+func (r *PullRequestChangeRepository) AddChanges(ctx context.Context, prID string, changes []entity.PullRequestChange) error {
+	if len(changes) == 0 {
+		return nil
+	}
+
+	changedAt := time.Now()
+	batch := &pgx.Batch{}
+	for i := range changes {
+		changes[i].ChangedAt = changedAt
+		batch.Queue(queryAddChange, prID, changes[i].Field, changes[i].OldValue, changes[i].NewValue, changedAt)
+	}
+
+	if err := conn(ctx, r.pool).SendBatch(ctx, batch).Close(); err != nil {
+		return fmt.Errorf("add pull request changes: %w", err)
+	}
+
+	return nil
+}
+
+// GetChanges получает историю правок PR в порядке внесения
+func (r *PullRequestChangeRepository) GetChanges(ctx context.Context, prID string) ([]entity.PullRequestChange, error) {
+	rows, err := conn(ctx, r.pool).Query(ctx, queryGetChanges, prID)
+	if err != nil {
+		return nil, fmt.Errorf("get pull request changes: %w", err)
+	}
+	defer rows.Close()
+
+	changes := []entity.PullRequestChange{}
+	for rows.Next() {
+		var change entity.PullRequestChange
+		err := rows.Scan(
+			&change.Field,
+			&change.OldValue,
+			&change.NewValue,
+			&change.ChangedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("scan pull request change: %w", err)
+		}
+		changes = append(changes, change)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate pull request changes: %w", err)
+	}
+
+	return changes, nil
+}
@@ -8,25 +8,58 @@ import (
 	"time"
 
 	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
 const (
 	queryCreatePR = `
-		INSERT INTO pull_requests (pull_request_id, pull_request_name, author_id, status, created_at, merged_at)
-		VALUES ($1, $2, $3, $4, $5, $6)
+		INSERT INTO pull_requests (pull_request_id, pull_request_name, description, labels, metadata, changed_files, required_tags, author_id, status, created_at, merged_at, need_more_reviewers)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+		RETURNING version
+	`
+
+	// prColumns — колонки PR вместе с ревьюверами в порядке назначения и решениями ревьюверов.
+	// Связанные данные читаются в том же запросе, чтобы списки PR не требовали запроса на каждую строку
+	prColumns = `
+		pr.pull_request_id, pr.pull_request_name, pr.description, pr.labels, pr.metadata, pr.changed_files, pr.required_tags, pr.author_id, pr.status, pr.created_at, pr.merged_at, pr.need_more_reviewers, pr.version,
+		ARRAY(
+			SELECT r.user_id
+			FROM pull_request_reviewers r
+			WHERE r.pull_request_id = pr.pull_request_id
+			ORDER BY r.assigned_at, r.id
+		),
+		COALESCE((
+			SELECT json_agg(json_build_object(
+				'reviewer_id', d.reviewer_id,
+				'decision', d.decision,
+				'comment', d.comment,
+				'created_at', d.created_at
+			) ORDER BY d.id)
+			FROM review_decisions d
+			WHERE d.pull_request_id = pr.pull_request_id
+		), '[]'::json)
 	`
 
 	queryGetPRByID = `
-		SELECT pull_request_id, pull_request_name, author_id, status, created_at, merged_at
-		FROM pull_requests
-		WHERE pull_request_id = $1
+		SELECT ` + prColumns + `
+		FROM pull_requests pr
+		WHERE pr.pull_request_id = $1
 	`
 
+	queryGetPRByIDForUpdate = queryGetPRByID + ` FOR UPDATE OF pr`
+
 	queryUpdatePR = `
 		UPDATE pull_requests
-		SET pull_request_name = $2, status = $3, merged_at = $4
-		WHERE pull_request_id = $1
+		SET pull_request_name = $2, description = $3, labels = $4, metadata = $5, status = $6, merged_at = $7, need_more_reviewers = $8, version = version + 1
+		WHERE pull_request_id = $1 AND version = $9
+		RETURNING version
+	`
+
+	querySetNeedMoreReviewers = `
+		UPDATE pull_requests
+		SET need_more_reviewers = $2, version = version + 1
+		WHERE pull_request_id = $1 AND version = $3
 	`
 
 	queryExistsPR = `
@@ -34,47 +67,64 @@ const (
 	`
 
 	queryGetByReviewer = `
-		SELECT DISTINCT pr.pull_request_id, pr.pull_request_name, pr.author_id, pr.status, pr.created_at, pr.merged_at
+		SELECT ` + prColumns + `
 		FROM pull_requests pr
-		INNER JOIN pull_request_reviewers prr ON pr.pull_request_id = prr.pull_request_id
-		WHERE prr.user_id = $1
+		WHERE EXISTS (
+			SELECT 1 FROM pull_request_reviewers prr
+			WHERE prr.pull_request_id = pr.pull_request_id AND prr.user_id = $1
+		)
 		ORDER BY pr.created_at DESC
 	`
 
 	queryGetOpenPRsByReviewers = `
-		SELECT DISTINCT pr.pull_request_id, pr.pull_request_name, pr.author_id, pr.status, pr.created_at, pr.merged_at
+		SELECT ` + prColumns + `
 		FROM pull_requests pr
-		INNER JOIN pull_request_reviewers prr ON pr.pull_request_id = prr.pull_request_id
-		WHERE prr.user_id = ANY($1) AND pr.status = $2
+		WHERE pr.status = $2 AND EXISTS (
+			SELECT 1 FROM pull_request_reviewers prr
+			WHERE prr.pull_request_id = pr.pull_request_id AND prr.user_id = ANY($1)
+		)
 		ORDER BY pr.created_at DESC
 	`
+
+	queryGetOpenNeedingReviewers = `
+		SELECT ` + prColumns + `
+		FROM pull_requests pr
+		WHERE pr.status = $1 AND pr.need_more_reviewers
+		ORDER BY pr.created_at
+	`
 )
 
 type PullRequestRepository struct {
-	pool         *pgxpool.Pool
-	reviewerRepo *ReviewerRepository
+	pool *pgxpool.Pool
 }
 
-func NewPullRequestRepository(pool *pgxpool.Pool, reviewerRepo *ReviewerRepository) *PullRequestRepository {
-	return &PullRequestRepository{
-		pool:         pool,
-		reviewerRepo: reviewerRepo,
-	}
+func NewPullRequestRepository(pool *pgxpool.Pool) *PullRequestRepository {
+	return &PullRequestRepository{pool: pool}
 }
 
 // Create создает новый PR
 func (r *PullRequestRepository) Create(ctx context.Context, pr *entity.PullRequest) error {
 	now := time.Now()
-	_, err := r.pool.Exec(ctx, queryCreatePR,
+	err := conn(ctx, r.pool).QueryRow(ctx, queryCreatePR,
 		pr.PullRequestID,
 		pr.PullRequestName,
+		pr.Description,
+		labelsOrEmpty(pr.Labels),
+		metadataOrEmpty(pr.Metadata),
+		labelsOrEmpty(pr.ChangedFiles),
+		labelsOrEmpty(pr.RequiredTags),
 		pr.AuthorID,
 		pr.Status,
 		now,
 		pr.MergedAt,
-	)
+		pr.NeedMoreReviewers,
+	).Scan(&pr.Version)
 
 	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == uniqueViolationCode {
+			return entity.ErrPRExists
+		}
 		return fmt.Errorf("create pull request: %w", err)
 	}
 
@@ -83,15 +133,17 @@ func (r *PullRequestRepository) Create(ctx context.Context, pr *entity.PullReque
 
 // GetByID получает PR по ID с ревьюверами
 func (r *PullRequestRepository) GetByID(ctx context.Context, prID string) (*entity.PullRequest, error) {
+	return r.getByID(ctx, queryGetPRByID, prID)
+}
+
+// GetByIDForUpdate получает PR по ID с ревьюверами и блокирует строку PR до конца транзакции
+func (r *PullRequestRepository) GetByIDForUpdate(ctx context.Context, prID string) (*entity.PullRequest, error) {
+	return r.getByID(ctx, queryGetPRByIDForUpdate, prID)
+}
+
+func (r *PullRequestRepository) getByID(ctx context.Context, query, prID string) (*entity.PullRequest, error) {
 	var pr entity.PullRequest
-	err := r.pool.QueryRow(ctx, queryGetPRByID, prID).Scan(
-		&pr.PullRequestID,
-		&pr.PullRequestName,
-		&pr.AuthorID,
-		&pr.Status,
-		&pr.CreatedAt,
-		&pr.MergedAt,
-	)
+	err := scanPullRequest(conn(ctx, r.pool).QueryRow(ctx, query, prID), &pr)
 
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
@@ -100,40 +152,49 @@ func (r *PullRequestRepository) GetByID(ctx context.Context, prID string) (*enti
 		return nil, fmt.Errorf("get pull request by id: %w", err)
 	}
 
-	// Получаем ревьюверов
-	reviewers, err := r.reviewerRepo.GetReviewers(ctx, prID)
-	if err != nil {
-		return nil, fmt.Errorf("get reviewers: %w", err)
-	}
-	pr.AssignedReviewers = reviewers
-
 	return &pr, nil
 }
 
-// Update обновляет PR
+// Update обновляет PR, если его версия не изменилась с момента чтения, и увеличивает версию
 func (r *PullRequestRepository) Update(ctx context.Context, pr *entity.PullRequest) error {
-	result, err := r.pool.Exec(ctx, queryUpdatePR,
+	err := conn(ctx, r.pool).QueryRow(ctx, queryUpdatePR,
 		pr.PullRequestID,
 		pr.PullRequestName,
+		pr.Description,
+		labelsOrEmpty(pr.Labels),
+		metadataOrEmpty(pr.Metadata),
 		pr.Status,
 		pr.MergedAt,
-	)
+		pr.NeedMoreReviewers,
+		pr.Version,
+	).Scan(&pr.Version)
 
 	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return r.versionConflictOrNotFound(ctx, pr.PullRequestID)
+		}
 		return fmt.Errorf("update pull request: %w", err)
 	}
 
-	if result.RowsAffected() == 0 {
+	return nil
+}
+
+// versionConflictOrNotFound различает устаревшую версию и отсутствие PR после неудачного обновления
+func (r *PullRequestRepository) versionConflictOrNotFound(ctx context.Context, prID string) error {
+	exists, err := r.Exists(ctx, prID)
+	if err != nil {
+		return err
+	}
+	if !exists {
 		return entity.ErrPRNotFound
 	}
-
-	return nil
+	return entity.ErrVersionConflict
 }
 
 // Exists проверяет существование PR
 func (r *PullRequestRepository) Exists(ctx context.Context, prID string) (bool, error) {
 	var exists bool
-	err := r.pool.QueryRow(ctx, queryExistsPR, prID).Scan(&exists)
+	err := conn(ctx, r.pool).QueryRow(ctx, queryExistsPR, prID).Scan(&exists)
 	if err != nil {
 		return false, fmt.Errorf("check pr exists: %w", err)
 	}
@@ -143,40 +204,10 @@ func (r *PullRequestRepository) Exists(ctx context.Context, prID string) (bool,
 
 // GetByReviewer получает PR'ы где пользователь назначен ревьювером
 func (r *PullRequestRepository) GetByReviewer(ctx context.Context, userID string) ([]entity.PullRequest, error) {
-	rows, err := r.pool.Query(ctx, queryGetByReviewer, userID)
+	prs, err := r.queryPullRequests(ctx, queryGetByReviewer, userID)
 	if err != nil {
 		return nil, fmt.Errorf("get prs by reviewer: %w", err)
 	}
-	defer rows.Close()
-
-	var prs []entity.PullRequest
-	for rows.Next() {
-		var pr entity.PullRequest
-		err := rows.Scan(
-			&pr.PullRequestID,
-			&pr.PullRequestName,
-			&pr.AuthorID,
-			&pr.Status,
-			&pr.CreatedAt,
-			&pr.MergedAt,
-		)
-		if err != nil {
-			return nil, fmt.Errorf("scan pull request: %w", err)
-		}
-
-		// Получаем ревьюверов для каждого PR
-		reviewers, err := r.reviewerRepo.GetReviewers(ctx, pr.PullRequestID)
-		if err != nil {
-			return nil, fmt.Errorf("get reviewers for pr %s: %w", pr.PullRequestID, err)
-		}
-		pr.AssignedReviewers = reviewers
-
-		prs = append(prs, pr)
-	}
-
-	if err := rows.Err(); err != nil {
-		return nil, fmt.Errorf("iterate pull requests: %w", err)
-	}
 
 	return prs, nil
 }
@@ -187,34 +218,38 @@ func (r *PullRequestRepository) GetOpenPRsByReviewers(ctx context.Context, revie
 		return []entity.PullRequest{}, nil
 	}
 
-	rows, err := r.pool.Query(ctx, queryGetOpenPRsByReviewers, reviewerIDs, entity.PRStatusOpen)
+	prs, err := r.queryPullRequests(ctx, queryGetOpenPRsByReviewers, reviewerIDs, entity.PRStatusOpen)
 	if err != nil {
 		return nil, fmt.Errorf("get open prs by reviewers: %w", err)
 	}
+
+	return prs, nil
+}
+
+// GetOpenNeedingReviewers получает открытые PR'ы, которым не хватает ревьюверов
+func (r *PullRequestRepository) GetOpenNeedingReviewers(ctx context.Context) ([]entity.PullRequest, error) {
+	prs, err := r.queryPullRequests(ctx, queryGetOpenNeedingReviewers, entity.PRStatusOpen)
+	if err != nil {
+		return nil, fmt.Errorf("get open prs needing reviewers: %w", err)
+	}
+
+	return prs, nil
+}
+
+// queryPullRequests выполняет запрос списка PR, выбирающий колонки prColumns
+func (r *PullRequestRepository) queryPullRequests(ctx context.Context, query string, args ...any) ([]entity.PullRequest, error) {
+	rows, err := conn(ctx, r.pool).Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
 	defer rows.Close()
 
 	var prs []entity.PullRequest
 	for rows.Next() {
 		var pr entity.PullRequest
-		err := rows.Scan(
-			&pr.PullRequestID,
-			&pr.PullRequestName,
-			&pr.AuthorID,
-			&pr.Status,
-			&pr.CreatedAt,
-			&pr.MergedAt,
-		)
-		if err != nil {
+		if err := scanPullRequest(rows, &pr); err != nil {
 			return nil, fmt.Errorf("scan pull request: %w", err)
 		}
-
-		// Получаем ревьюверов
-		reviewers, err := r.reviewerRepo.GetReviewers(ctx, pr.PullRequestID)
-		if err != nil {
-			return nil, fmt.Errorf("get reviewers: %w", err)
-		}
-		pr.AssignedReviewers = reviewers
-
 		prs = append(prs, pr)
 	}
 
@@ -224,3 +259,40 @@ func (r *PullRequestRepository) GetOpenPRsByReviewers(ctx context.Context, revie
 
 	return prs, nil
 }
+
+// scanPullRequest читает строку PR, выбранную колонками prColumns
+func scanPullRequest(row pgx.Row, pr *entity.PullRequest) error {
+	return row.Scan(
+		&pr.PullRequestID,
+		&pr.PullRequestName,
+		&pr.Description,
+		&pr.Labels,
+		&pr.Metadata,
+		&pr.ChangedFiles,
+		&pr.RequiredTags,
+		&pr.AuthorID,
+		&pr.Status,
+		&pr.CreatedAt,
+		&pr.MergedAt,
+		&pr.NeedMoreReviewers,
+		&pr.Version,
+		&pr.AssignedReviewers,
+		&pr.Reviews,
+	)
+}
+
+// labelsOrEmpty заменяет отсутствующий список строк (метки, измененные файлы, теги) пустым, чтобы в JSONB не попадал null
+func labelsOrEmpty(labels []string) []string {
+	if labels == nil {
+		return []string{}
+	}
+	return labels
+}
+
+// metadataOrEmpty заменяет отсутствующие метаданные пустым объектом
+func metadataOrEmpty(metadata map[string]string) map[string]string {
+	if metadata == nil {
+		return map[string]string{}
+	}
+	return metadata
+}
@@ -0,0 +1,69 @@
+package postgres_test
+
+import (
+	"context"
+	"fmt"
+	"internship/internal/repository/postgres"
+	"internship/internal/repository/repotest"
+	"internship/migrations"
+	"os"
+	"testing"
+)
+
+// dsnEnv — переменная окружения со строкой подключения к отдельной тестовой базе.
+// Перед каждой проверкой таблицы очищаются, поэтому рабочую базу указывать нельзя
+const dsnEnv = "REPOTEST_POSTGRES_DSN"
+
+const queryTruncate = `TRUNCATE teams, users, pull_requests, pull_request_reviewers, audit_log RESTART IDENTITY CASCADE`
+
+// newFactory подключается к тестовой базе из dsnEnv, применяет миграции и возвращает фабрику репозиториев,
+// очищающую таблицы перед каждой проверкой. Если переменная не задана, тест пропускается
+func newFactory(tb testing.TB) repotest.Factory {
+	tb.Helper()
+
+	dsn := os.Getenv(dsnEnv)
+	if dsn == "" {
+		tb.Skipf("%s is not set", dsnEnv)
+	}
+
+	storage, err := postgres.NewDatabase(tb.Context(), dsn)
+	if err != nil {
+		tb.Fatal(err)
+	}
+	tb.Cleanup(func() { _ = storage.Close() })
+	pool := storage.GetPool()
+
+	migrator, err := postgres.NewMigrator(pool, migrations.FS)
+	if err != nil {
+		tb.Fatal(err)
+	}
+	if _, err := migrator.Up(tb.Context()); err != nil {
+		tb.Fatal(err)
+	}
+
+	return func(ctx context.Context) (*repotest.Repositories, func(), error) {
+		if _, err := pool.Exec(ctx, queryTruncate); err != nil {
+			return nil, nil, fmt.Errorf("truncate tables: %w", err)
+		}
+		return &repotest.Repositories{
+			Team:            postgres.NewTeamRepository(pool),
+			User:            postgres.NewUserRepository(pool),
+			PullRequest:     postgres.NewPullRequestRepository(pool),
+			Reviewer:        postgres.NewReviewerRepository(pool),
+			Review:          postgres.NewReviewDecisionRepository(pool),
+			Change:          postgres.NewPullRequestChangeRepository(pool),
+			AssignmentEvent: postgres.NewAssignmentEventRepository(pool),
+			Audit:           postgres.NewAuditRepository(pool),
+			Statistics:      postgres.NewStatisticsRepository(pool),
+			TxManager:       postgres.NewTxManager(pool),
+		}, func() {}, nil
+	}
+}
+
+func TestRepositories(t *testing.T) {
+	repotest.Run(t, newFactory(t))
+}
+
+func BenchmarkGetByReviewer(b *testing.B) {
+	repotest.BenchmarkGetByReviewer(b, newFactory(b))
+}
@@ -0,0 +1,77 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"internship/internal/domain/entity"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+const (
+	queryAddDecision = `
+		INSERT INTO review_decisions (pull_request_id, reviewer_id, decision, comment)
+		VALUES ($1, $2, $3, $4)
+		RETURNING created_at
+	`
+	queryGetDecisions = `
+		SELECT reviewer_id, decision, comment, created_at
+		FROM review_decisions
+		WHERE pull_request_id = $1
+		ORDER BY id
+	`
+)
+
+type ReviewDecisionRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewReviewDecisionRepository(pool *pgxpool.Pool) *ReviewDecisionRepository {
+	return &ReviewDecisionRepository{pool: pool}
+}
+
+// AddDecision сохраняет решение ревьювера по PR
+func (r *ReviewDecisionRepository) AddDecision(ctx context.Context, prID string, decision *entity.ReviewDecision) error {
+	err := conn(ctx, r.pool).QueryRow(ctx, queryAddDecision,
+		prID,
+		decision.ReviewerID,
+		decision.Decision,
+		decision.Comment,
+	).Scan(&decision.CreatedAt)
+
+	if err != nil {
+		return fmt.Errorf("add review decision: %w", err)
+	}
+
+	return nil
+}
+
+// GetDecisions получает решения ревьюверов по PR в порядке поступления
+func (r *ReviewDecisionRepository) GetDecisions(ctx context.Context, prID string) ([]entity.ReviewDecision, error) {
+	rows, err := conn(ctx, r.pool).Query(ctx, queryGetDecisions, prID)
+	if err != nil {
+		return nil, fmt.Errorf("get review decisions: %w", err)
+	}
+	defer rows.Close()
+
+	decisions := []entity.ReviewDecision{}
+	for rows.Next() {
+		var decision entity.ReviewDecision
+		err := rows.Scan(
+			&decision.ReviewerID,
+			&decision.Decision,
+			&decision.Comment,
+			&decision.CreatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("scan review decision: %w", err)
+		}
+		decisions = append(decisions, decision)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate review decisions: %w", err)
+	}
+
+	return decisions, nil
+}
@@ -3,6 +3,7 @@ package postgres
 import (
 	"context"
 	"fmt"
+	"internship/internal/domain/entity"
 
 	"github.com/jackc/pgx/v5/pgxpool"
 )
@@ -21,15 +22,11 @@ const (
 		SELECT user_id
 		FROM pull_request_reviewers
 		WHERE pull_request_id = $1
-		ORDER BY assigned_at
+		ORDER BY assigned_at, id
 	`
 	queryIsAssigned = `
 		SELECT EXISTS(SELECT 1 FROM pull_request_reviewers WHERE pull_request_id = $1 AND user_id = $2)
 	`
-	queryReplaceReviewer = `
-		DELETE FROM pull_request_reviewers
-		WHERE pull_request_id = $1 AND user_id = $2
-	`
 	queryInsertReviewer = `
 		INSERT INTO pull_request_reviewers (pull_request_id, user_id)
 		VALUES ($1, $2)
@@ -39,6 +36,13 @@ const (
 		DELETE FROM pull_request_reviewers
 		WHERE pull_request_id = $1 AND user_id = $2
 	`
+	queryCountOpenReviews = `
+		SELECT prr.user_id, COUNT(*)
+		FROM pull_request_reviewers prr
+		INNER JOIN pull_requests pr ON pr.pull_request_id = prr.pull_request_id
+		WHERE prr.user_id = ANY($1) AND pr.status = $2
+		GROUP BY prr.user_id
+	`
 )
 
 type ReviewerRepository struct {
@@ -51,7 +55,7 @@ func NewReviewerRepository(pool *pgxpool.Pool) *ReviewerRepository {
 
 // AssignReviewer назначает ревьювера на PR
 func (r *ReviewerRepository) AssignReviewer(ctx context.Context, prID, userID string) error {
-	_, err := r.pool.Exec(ctx, queryAssignReviewer, prID, userID)
+	_, err := conn(ctx, r.pool).Exec(ctx, queryAssignReviewer, prID, userID)
 	if err != nil {
 		return fmt.Errorf("assign reviewer: %w", err)
 	}
@@ -61,7 +65,7 @@ func (r *ReviewerRepository) AssignReviewer(ctx context.Context, prID, userID st
 
 // RemoveReviewer удаляет ревьювера с PR
 func (r *ReviewerRepository) RemoveReviewer(ctx context.Context, prID, userID string) error {
-	_, err := r.pool.Exec(ctx, queryRemoveReviewer, prID, userID)
+	_, err := conn(ctx, r.pool).Exec(ctx, queryRemoveReviewer, prID, userID)
 	if err != nil {
 		return fmt.Errorf("remove reviewer: %w", err)
 	}
@@ -71,7 +75,7 @@ func (r *ReviewerRepository) RemoveReviewer(ctx context.Context, prID, userID st
 
 // GetReviewers получает список ревьюверов для PR
 func (r *ReviewerRepository) GetReviewers(ctx context.Context, prID string) ([]string, error) {
-	rows, err := r.pool.Query(ctx, queryGetReviewers, prID)
+	rows, err := conn(ctx, r.pool).Query(ctx, queryGetReviewers, prID)
 	if err != nil {
 		return nil, fmt.Errorf("get reviewers: %w", err)
 	}
@@ -96,7 +100,7 @@ func (r *ReviewerRepository) GetReviewers(ctx context.Context, prID string) ([]s
 // IsAssigned проверяет, назначен ли пользователь ревьювером на PR
 func (r *ReviewerRepository) IsAssigned(ctx context.Context, prID, userID string) (bool, error) {
 	var assigned bool
-	err := r.pool.QueryRow(ctx, queryIsAssigned, prID, userID).Scan(&assigned)
+	err := conn(ctx, r.pool).QueryRow(ctx, queryIsAssigned, prID, userID).Scan(&assigned)
 	if err != nil {
 		return false, fmt.Errorf("check is assigned: %w", err)
 	}
@@ -106,27 +110,48 @@ func (r *ReviewerRepository) IsAssigned(ctx context.Context, prID, userID string
 
 // ReplaceReviewer заменяет одного ревьювера на другого в транзакции
 func (r *ReviewerRepository) ReplaceReviewer(ctx context.Context, prID, oldUserID, newUserID string) error {
-	tx, err := r.pool.Begin(ctx)
-	if err != nil {
-		return fmt.Errorf("begin transaction: %w", err)
+	return runInTx(ctx, r.pool, func(ctx context.Context) error {
+		q := conn(ctx, r.pool)
+
+		// Удаляем старого ревьювера
+		if _, err := q.Exec(ctx, queryDeleteReviewer, prID, oldUserID); err != nil {
+			return fmt.Errorf("remove old reviewer: %w", err)
+		}
+
+		// Добавляем нового ревьювера
+		if _, err := q.Exec(ctx, queryInsertReviewer, prID, newUserID); err != nil {
+			return fmt.Errorf("assign new reviewer: %w", err)
+		}
+
+		return nil
+	})
+}
+
+// CountOpenReviews возвращает число открытых PR на ревью у каждого из пользователей одним запросом
+func (r *ReviewerRepository) CountOpenReviews(ctx context.Context, userIDs []string) (map[string]int, error) {
+	counts := make(map[string]int, len(userIDs))
+	if len(userIDs) == 0 {
+		return counts, nil
 	}
-	defer func() { _ = tx.Rollback(ctx) }()
 
-	// Удаляем старого ревьювера
-	_, err = tx.Exec(ctx, queryDeleteReviewer, prID, oldUserID)
+	rows, err := conn(ctx, r.pool).Query(ctx, queryCountOpenReviews, userIDs, entity.PRStatusOpen)
 	if err != nil {
-		return fmt.Errorf("remove old reviewer: %w", err)
+		return nil, fmt.Errorf("count open reviews: %w", err)
 	}
+	defer rows.Close()
 
-	// Добавляем нового ревьювера
-	_, err = tx.Exec(ctx, queryInsertReviewer, prID, newUserID)
-	if err != nil {
-		return fmt.Errorf("assign new reviewer: %w", err)
+	for rows.Next() {
+		var userID string
+		var count int
+		if err := rows.Scan(&userID, &count); err != nil {
+			return nil, fmt.Errorf("scan open reviews count: %w", err)
+		}
+		counts[userID] = count
 	}
 
-	if err := tx.Commit(ctx); err != nil {
-		return fmt.Errorf("commit transaction: %w", err)
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate open reviews counts: %w", err)
 	}
 
-	return nil
+	return counts, nil
 }
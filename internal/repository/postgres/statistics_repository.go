@@ -19,7 +19,9 @@ const (
 		SELECT
 			COUNT(*) as total_prs,
 			COUNT(CASE WHEN status = 'OPEN' THEN 1 END) as open_prs,
-			COUNT(CASE WHEN status = 'MERGED' THEN 1 END) as merged_prs
+			COUNT(CASE WHEN status = 'MERGED' THEN 1 END) as merged_prs,
+			COUNT(CASE WHEN status = 'DRAFT' THEN 1 END) as draft_prs,
+			COUNT(CASE WHEN status = 'CLOSED' THEN 1 END) as closed_prs
 		FROM pull_requests
 	`
 )
@@ -34,7 +36,7 @@ func NewStatisticsRepository(pool *pgxpool.Pool) *StatisticsRepository {
 
 // GetAssignmentStats возвращает статистику назначений по пользователям
 func (r *StatisticsRepository) GetAssignmentStats(ctx context.Context) (map[string]int, error) {
-	rows, err := r.pool.Query(ctx, queryGetAssignmentStats)
+	rows, err := conn(ctx, r.pool).Query(ctx, queryGetAssignmentStats)
 	if err != nil {
 		return nil, fmt.Errorf("get assignment stats: %w", err)
 	}
@@ -59,8 +61,8 @@ func (r *StatisticsRepository) GetAssignmentStats(ctx context.Context) (map[stri
 
 // GetPRStats возвращает общую статистику по PR
 func (r *StatisticsRepository) GetPRStats(ctx context.Context) (map[string]interface{}, error) {
-	var totalPRs, openPRs, mergedPRs int
-	err := r.pool.QueryRow(ctx, queryGetPRStats).Scan(&totalPRs, &openPRs, &mergedPRs)
+	var totalPRs, openPRs, mergedPRs, draftPRs, closedPRs int
+	err := conn(ctx, r.pool).QueryRow(ctx, queryGetPRStats).Scan(&totalPRs, &openPRs, &mergedPRs, &draftPRs, &closedPRs)
 	if err != nil {
 		return nil, fmt.Errorf("get pr stats: %w", err)
 	}
@@ -69,6 +71,8 @@ func (r *StatisticsRepository) GetPRStats(ctx context.Context) (map[string]inter
 		"total_prs":  totalPRs,
 		"open_prs":   openPRs,
 		"merged_prs": mergedPRs,
+		"draft_prs":  draftPRs,
+		"closed_prs": closedPRs,
 	}
 
 	return stats, nil
@@ -7,13 +7,29 @@ import (
 	"internship/internal/domain/entity"
 
 	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
 const (
-	queryCreateTeam      = `INSERT INTO teams (team_name) VALUES ($1)`
-	queryGetTeamByName   = `SELECT team_name FROM teams WHERE team_name = $1`
+	queryCreateTeam = `
+		INSERT INTO teams (team_name, default_max_open_reviews, min_reviewers, max_reviewers)
+		VALUES ($1, $2, $3, $4)`
+	queryGetTeamByName = `
+		SELECT team_name, default_max_open_reviews, min_reviewers, max_reviewers
+		FROM teams
+		WHERE team_name = $1`
 	queryCheckTeamExists = `SELECT EXISTS(SELECT 1 FROM teams WHERE team_name = $1)`
+
+	queryUpdateReviewerSettings = `UPDATE teams SET min_reviewers = $2, max_reviewers = $3 WHERE team_name = $1`
+
+	queryDeleteFallbackTeams = `DELETE FROM team_fallbacks WHERE team_name = $1`
+	queryAddFallbackTeam     = `INSERT INTO team_fallbacks (team_name, position, fallback_team_name) VALUES ($1, $2, $3)`
+	queryGetFallbackTeams    = `SELECT fallback_team_name FROM team_fallbacks WHERE team_name = $1 ORDER BY position`
+
+	queryDeleteCodeOwnerRules = `DELETE FROM code_owner_rules WHERE team_name = $1`
+	queryAddCodeOwnerRule     = `INSERT INTO code_owner_rules (team_name, position, pattern, owners) VALUES ($1, $2, $3, $4)`
+	queryGetCodeOwnerRules    = `SELECT pattern, owners FROM code_owner_rules WHERE team_name = $1 ORDER BY position`
 )
 
 type TeamRepository struct {
@@ -27,8 +43,12 @@ func NewTeamRepository(pool *pgxpool.Pool) *TeamRepository {
 // Create создает новую команду
 func (r *TeamRepository) Create(ctx context.Context, team *entity.Team) error {
 
-	_, err := r.pool.Exec(ctx, queryCreateTeam, team.TeamName)
+	_, err := conn(ctx, r.pool).Exec(ctx, queryCreateTeam, team.TeamName, team.DefaultMaxOpenReviews, team.MinReviewers, team.MaxReviewers)
 	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == uniqueViolationCode {
+			return fmt.Errorf("create team: %w", entity.ErrTeamExists)
+		}
 		return fmt.Errorf("create team: %w", err)
 	}
 
@@ -38,8 +58,11 @@ func (r *TeamRepository) Create(ctx context.Context, team *entity.Team) error {
 // GetByName получает команду по имени
 func (r *TeamRepository) GetByName(ctx context.Context, teamName string) (*entity.Team, error) {
 	var team entity.Team
-	err := r.pool.QueryRow(ctx, queryGetTeamByName, teamName).Scan(
+	err := conn(ctx, r.pool).QueryRow(ctx, queryGetTeamByName, teamName).Scan(
 		&team.TeamName,
+		&team.DefaultMaxOpenReviews,
+		&team.MinReviewers,
+		&team.MaxReviewers,
 	)
 
 	if err != nil {
@@ -55,10 +78,101 @@ func (r *TeamRepository) GetByName(ctx context.Context, teamName string) (*entit
 // Exists проверяет существование команды
 func (r *TeamRepository) Exists(ctx context.Context, teamName string) (bool, error) {
 	var exists bool
-	err := r.pool.QueryRow(ctx, queryCheckTeamExists, teamName).Scan(&exists)
+	err := conn(ctx, r.pool).QueryRow(ctx, queryCheckTeamExists, teamName).Scan(&exists)
 	if err != nil {
 		return false, fmt.Errorf("check team exists: %w", err)
 	}
 
 	return exists, nil
 }
+
+// UpdateReviewerSettings сохраняет настройки числа ревьюверов команды
+func (r *TeamRepository) UpdateReviewerSettings(ctx context.Context, teamName string, settings entity.ReviewerSettings) error {
+	result, err := conn(ctx, r.pool).Exec(ctx, queryUpdateReviewerSettings, teamName, settings.MinReviewers, settings.MaxReviewers)
+	if err != nil {
+		return fmt.Errorf("update reviewer settings: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return entity.ErrTeamNotFound
+	}
+
+	return nil
+}
+
+// SetFallbackTeams заменяет резервные команды команды одним батчем, сохраняя их порядок
+func (r *TeamRepository) SetFallbackTeams(ctx context.Context, teamName string, fallbackTeams []string) error {
+	batch := &pgx.Batch{}
+	batch.Queue(queryDeleteFallbackTeams, teamName)
+	for position, fallbackTeam := range fallbackTeams {
+		batch.Queue(queryAddFallbackTeam, teamName, position, fallbackTeam)
+	}
+
+	if err := conn(ctx, r.pool).SendBatch(ctx, batch).Close(); err != nil {
+		return fmt.Errorf("set fallback teams: %w", err)
+	}
+
+	return nil
+}
+
+// GetFallbackTeams получает резервные команды команды по порядку
+func (r *TeamRepository) GetFallbackTeams(ctx context.Context, teamName string) ([]string, error) {
+	rows, err := conn(ctx, r.pool).Query(ctx, queryGetFallbackTeams, teamName)
+	if err != nil {
+		return nil, fmt.Errorf("get fallback teams: %w", err)
+	}
+
+	defer rows.Close()
+
+	fallbackTeams := []string{}
+	for rows.Next() {
+		var fallbackTeam string
+		if err := rows.Scan(&fallbackTeam); err != nil {
+			return nil, fmt.Errorf("scan fallback team: %w", err)
+		}
+		fallbackTeams = append(fallbackTeams, fallbackTeam)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate fallback teams: %w", err)
+	}
+
+	return fallbackTeams, nil
+}
+
+// SetCodeOwnerRules заменяет правила владельцев кода команды одним батчем, сохраняя их порядок
+func (r *TeamRepository) SetCodeOwnerRules(ctx context.Context, teamName string, rules []entity.CodeOwnerRule) error {
+	batch := &pgx.Batch{}
+	batch.Queue(queryDeleteCodeOwnerRules, teamName)
+	for position, rule := range rules {
+		batch.Queue(queryAddCodeOwnerRule, teamName, position, rule.Pattern, rule.Owners)
+	}
+
+	if err := conn(ctx, r.pool).SendBatch(ctx, batch).Close(); err != nil {
+		return fmt.Errorf("set code owner rules: %w", err)
+	}
+
+	return nil
+}
+
+// GetCodeOwnerRules получает правила владельцев кода команды по порядку
+func (r *TeamRepository) GetCodeOwnerRules(ctx context.Context, teamName string) ([]entity.CodeOwnerRule, error) {
+	rows, err := conn(ctx, r.pool).Query(ctx, queryGetCodeOwnerRules, teamName)
+	if err != nil {
+		return nil, fmt.Errorf("get code owner rules: %w", err)
+	}
+
+	defer rows.Close()
+
+	rules := []entity.CodeOwnerRule{}
+	for rows.Next() {
+		var rule entity.CodeOwnerRule
+		if err := rows.Scan(&rule.Pattern, &rule.Owners); err != nil {
+			return nil, fmt.Errorf("scan code owner rule: %w", err)
+		}
+		rules = append(rules, rule)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate code owner rules: %w", err)
+	}
+
+	return rules, nil
+}
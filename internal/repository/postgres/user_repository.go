@@ -12,12 +12,14 @@ import (
 
 const (
 	queryCreateOrUpdateUser = `
-		INSERT INTO users (user_id, username, team_name, is_active)
-		VALUES ($1, $2, $3, $4)
+		INSERT INTO users (user_id, username, team_name, is_active, max_open_reviews, expertise_tags)
+		VALUES ($1, $2, $3, $4, $5, $6)
 		ON CONFLICT (user_id) DO UPDATE SET
 			username = EXCLUDED.username,
 			team_name = EXCLUDED.team_name,
-			is_active = EXCLUDED.is_active
+			is_active = EXCLUDED.is_active,
+			max_open_reviews = EXCLUDED.max_open_reviews,
+			expertise_tags = EXCLUDED.expertise_tags
 	`
 
 	querySetIsActive = `
@@ -28,7 +30,13 @@ const (
 
 	queryUpdate = `
 		UPDATE users
-		SET username = $2, team_name = $3, is_active = $4
+		SET username = $2, team_name = $3, is_active = $4, max_open_reviews = $5, expertise_tags = $6
+		WHERE user_id = $1
+	`
+
+	querySetExpertiseTags = `
+		UPDATE users
+		SET expertise_tags = $2
 		WHERE user_id = $1
 	`
 
@@ -39,13 +47,13 @@ const (
 	`
 
 	queryGetByID = `
-		SELECT user_id, username, team_name, is_active
+		SELECT user_id, username, team_name, is_active, max_open_reviews, expertise_tags
 		FROM users
 		WHERE user_id = $1
 	`
 
 	queryGetByTeamName = `
-		SELECT user_id, username, team_name, is_active
+		SELECT user_id, username, team_name, is_active, max_open_reviews, expertise_tags
 		FROM users
 		WHERE team_name = $1
 	`
@@ -61,40 +69,36 @@ func NewUserRepository(pool *pgxpool.Pool) *UserRepository {
 
 // BatchCreateOrUpdate создает или обновляет пользователей
 func (r *UserRepository) BatchCreateOrUpdate(ctx context.Context, users []*entity.User) error {
-	tx, err := r.pool.Begin(ctx)
-	if err != nil {
-		return fmt.Errorf("begin transaction: %w", err)
-	}
-	defer func() { _ = tx.Rollback(ctx) }()
-
-	for _, user := range users {
-		_, err := tx.Exec(ctx, queryCreateOrUpdateUser,
-			user.UserID,
-			user.Username,
-			user.TeamName,
-			user.IsActive,
-		)
-		if err != nil {
-			return fmt.Errorf("update user %s: %w", user.UserID, err)
+	return runInTx(ctx, r.pool, func(ctx context.Context) error {
+		q := conn(ctx, r.pool)
+		for _, user := range users {
+			_, err := q.Exec(ctx, queryCreateOrUpdateUser,
+				user.UserID,
+				user.Username,
+				user.TeamName,
+				user.IsActive,
+				user.MaxOpenReviews,
+				labelsOrEmpty(user.ExpertiseTags),
+			)
+			if err != nil {
+				return fmt.Errorf("update user %s: %w", user.UserID, err)
+			}
 		}
 
-	}
-
-	if err := tx.Commit(ctx); err != nil {
-		return fmt.Errorf("commit transaction: %w", err)
-	}
-
-	return nil
+		return nil
+	})
 }
 
 // Update обновляет данные пользователя
 func (r *UserRepository) Update(ctx context.Context, user *entity.User) error {
 
-	result, err := r.pool.Exec(ctx, queryUpdate,
+	result, err := conn(ctx, r.pool).Exec(ctx, queryUpdate,
 		user.UserID,
 		user.Username,
 		user.TeamName,
 		user.IsActive,
+		user.MaxOpenReviews,
+		labelsOrEmpty(user.ExpertiseTags),
 	)
 
 	if err != nil {
@@ -111,11 +115,13 @@ func (r *UserRepository) Update(ctx context.Context, user *entity.User) error {
 // GetByID получает пользователя по ID
 func (r *UserRepository) GetByID(ctx context.Context, userID string) (*entity.User, error) {
 	var user entity.User
-	err := r.pool.QueryRow(ctx, queryGetByID, userID).Scan(
+	err := conn(ctx, r.pool).QueryRow(ctx, queryGetByID, userID).Scan(
 		&user.UserID,
 		&user.Username,
 		&user.TeamName,
 		&user.IsActive,
+		&user.MaxOpenReviews,
+		&user.ExpertiseTags,
 	)
 
 	if err != nil {
@@ -131,7 +137,7 @@ func (r *UserRepository) GetByID(ctx context.Context, userID string) (*entity.Us
 // GetByTeamName получает всех пользователей команды
 func (r *UserRepository) GetByTeamName(ctx context.Context, teamName string) ([]entity.User, error) {
 
-	rows, err := r.pool.Query(ctx, queryGetByTeamName, teamName)
+	rows, err := conn(ctx, r.pool).Query(ctx, queryGetByTeamName, teamName)
 	if err != nil {
 		return nil, fmt.Errorf("get users by team: %w", err)
 	}
@@ -145,6 +151,8 @@ func (r *UserRepository) GetByTeamName(ctx context.Context, teamName string) ([]
 			&user.Username,
 			&user.TeamName,
 			&user.IsActive,
+			&user.MaxOpenReviews,
+			&user.ExpertiseTags,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("scan user: %w", err)
@@ -162,7 +170,7 @@ func (r *UserRepository) GetByTeamName(ctx context.Context, teamName string) ([]
 // SetIsActive устанавливает флаг активности пользователя
 func (r *UserRepository) SetIsActive(ctx context.Context, userID string, isActive bool) error {
 
-	result, err := r.pool.Exec(ctx, querySetIsActive, userID, isActive)
+	result, err := conn(ctx, r.pool).Exec(ctx, querySetIsActive, userID, isActive)
 	if err != nil {
 		return fmt.Errorf("set is_active: %w", err)
 	}
@@ -174,13 +182,69 @@ func (r *UserRepository) SetIsActive(ctx context.Context, userID string, isActiv
 	return nil
 }
 
-// DeactivateTeamMembers деактивирует всех участников команды
-func (r *UserRepository) DeactivateTeamMembers(ctx context.Context, teamName string) error {
+// SetExpertiseTags заменяет теги экспертизы пользователя
+func (r *UserRepository) SetExpertiseTags(ctx context.Context, userID string, tags []string) error {
 
-	_, err := r.pool.Exec(ctx, queryDeactivateTeamMembers, teamName)
+	result, err := conn(ctx, r.pool).Exec(ctx, querySetExpertiseTags, userID, labelsOrEmpty(tags))
 	if err != nil {
-		return fmt.Errorf("deactivate team members: %w", err)
+		return fmt.Errorf("set expertise tags: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return entity.ErrUserNotFound
 	}
 
 	return nil
 }
+
+// DeactivateTeamMembers деактивирует всех участников команды и применяет замены ревьюверов
+// открытых PR в одной транзакции. Запросы отправляются одним батчем
+func (r *UserRepository) DeactivateTeamMembers(ctx context.Context, teamName string, reassignments []entity.ReviewerReassignment) error {
+	batch := &pgx.Batch{}
+	batch.Queue(queryDeactivateTeamMembers, teamName)
+	for _, reassignment := range reassignments {
+		prID := reassignment.PullRequest.PullRequestID
+		for _, userID := range reassignment.RemovedReviewers {
+			batch.Queue(queryDeleteReviewer, prID, userID)
+		}
+		for _, userID := range reassignment.AddedReviewers {
+			batch.Queue(queryInsertReviewer, prID, userID)
+		}
+		batch.Queue(querySetNeedMoreReviewers, prID, reassignment.PullRequest.NeedMoreReviewers, reassignment.PullRequest.Version)
+	}
+
+	return runInTx(ctx, r.pool, func(ctx context.Context) error {
+		results := conn(ctx, r.pool).SendBatch(ctx, batch)
+		defer func() { _ = results.Close() }()
+
+		if _, err := results.Exec(); err != nil {
+			return fmt.Errorf("deactivate team members: %w", err)
+		}
+
+		for _, reassignment := range reassignments {
+			for range len(reassignment.RemovedReviewers) + len(reassignment.AddedReviewers) {
+				if _, err := results.Exec(); err != nil {
+					return fmt.Errorf("reassign reviewers of pr %s: %w", reassignment.PullRequest.PullRequestID, err)
+				}
+			}
+
+			// PR изменен параллельно после чтения — откатываем всю деактивацию
+			tag, err := results.Exec()
+			if err != nil {
+				return fmt.Errorf("update pr %s: %w", reassignment.PullRequest.PullRequestID, err)
+			}
+			if tag.RowsAffected() == 0 {
+				return fmt.Errorf("update pr %s: %w", reassignment.PullRequest.PullRequestID, entity.ErrVersionConflict)
+			}
+		}
+
+		if err := results.Close(); err != nil {
+			return fmt.Errorf("deactivate team members: %w", err)
+		}
+
+		for i := range reassignments {
+			reassignments[i].PullRequest.Version++
+		}
+		return nil
+	})
+}
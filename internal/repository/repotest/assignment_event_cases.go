@@ -0,0 +1,106 @@
+package repotest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"internship/internal/domain/entity"
+)
+
+func assignmentEventCases() []Case {
+	return []Case{
+		{Name: "assignment event/history in order", Run: func(ctx context.Context, repos *Repositories) error {
+			if err := seedTeam(ctx, repos, testTeam, 4); err != nil {
+				return err
+			}
+			if _, err := seedPullRequest(ctx, repos, "pr1", userID(testTeam, 1)); err != nil {
+				return err
+			}
+			if _, err := seedPullRequest(ctx, repos, "pr2", userID(testTeam, 1)); err != nil {
+				return err
+			}
+
+			created := []entity.ReviewerAssignmentEvent{
+				assignmentEvent("pr1", entity.AssignmentEventAssign, userID(testTeam, 2), "", entity.AssignmentReasonAutoCreate, "alice"),
+				assignmentEvent("pr1", entity.AssignmentEventAssign, userID(testTeam, 3), "", entity.AssignmentReasonAutoCreate, "alice"),
+			}
+			replaced := []entity.ReviewerAssignmentEvent{
+				assignmentEvent("pr1", entity.AssignmentEventReplace, userID(testTeam, 4), userID(testTeam, 2), entity.AssignmentReasonManualReassign, "bob"),
+				assignmentEvent("pr1", entity.AssignmentEventUnassign, userID(testTeam, 3), "", entity.AssignmentReasonTeamDeactivation, entity.ActorSystem),
+			}
+			if err := repos.AssignmentEvent.AddEvents(ctx, created); err != nil {
+				return err
+			}
+			if err := repos.AssignmentEvent.AddEvents(ctx, replaced); err != nil {
+				return err
+			}
+			if created[0].CreatedAt.IsZero() {
+				return fmt.Errorf("add events: created_at is not set")
+			}
+
+			events, err := repos.AssignmentEvent.GetEvents(ctx, "pr1")
+			if err != nil {
+				return err
+			}
+			empty, err := repos.AssignmentEvent.GetEvents(ctx, "pr2")
+			if err != nil {
+				return err
+			}
+
+			return first(
+				expectEqual("events", eventValues(events), eventValues(append(created, replaced...))),
+				expectEqual("events of pr without history", len(empty), 0),
+			)
+		}},
+		{Name: "assignment event/rolled back with transaction", Run: func(ctx context.Context, repos *Repositories) error {
+			if err := seedTeam(ctx, repos, testTeam, 2); err != nil {
+				return err
+			}
+			if _, err := seedPullRequest(ctx, repos, "pr1", userID(testTeam, 1)); err != nil {
+				return err
+			}
+
+			errRollback := errors.New("rollback")
+			err := repos.TxManager.WithinTransaction(ctx, func(ctx context.Context) error {
+				if err := repos.Reviewer.AssignReviewer(ctx, "pr1", userID(testTeam, 2)); err != nil {
+					return err
+				}
+				event := assignmentEvent("pr1", entity.AssignmentEventAssign, userID(testTeam, 2), "", entity.AssignmentReasonBackfill, entity.ActorSystem)
+				if err := repos.AssignmentEvent.AddEvents(ctx, []entity.ReviewerAssignmentEvent{event}); err != nil {
+					return err
+				}
+				return errRollback
+			})
+			if err := expectErr("transaction", err, errRollback); err != nil {
+				return err
+			}
+
+			events, err := repos.AssignmentEvent.GetEvents(ctx, "pr1")
+			if err != nil {
+				return err
+			}
+			return expectEqual("events after rollback", len(events), 0)
+		}},
+	}
+}
+
+func assignmentEvent(prID string, eventType entity.AssignmentEventType, userID, previousUserID string, reason entity.AssignmentReason, actor string) entity.ReviewerAssignmentEvent {
+	return entity.ReviewerAssignmentEvent{
+		PullRequestID:  prID,
+		Event:          eventType,
+		UserID:         userID,
+		PreviousUserID: previousUserID,
+		Reason:         reason,
+		Actor:          actor,
+	}
+}
+
+// eventValues описывает события без времени: хранилища сохраняют его с разной точностью
+func eventValues(events []entity.ReviewerAssignmentEvent) []string {
+	values := make([]string, 0, len(events))
+	for _, event := range events {
+		values = append(values, fmt.Sprintf("%s %s %s <- %q (%s by %s)",
+			event.PullRequestID, event.Event, event.UserID, event.PreviousUserID, event.Reason, event.Actor))
+	}
+	return values
+}
@@ -0,0 +1,123 @@
+package repotest
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"internship/internal/domain/entity"
+	"time"
+)
+
+func auditCases() []Case {
+	return []Case{
+		{Name: "audit/filter newest first", Run: func(ctx context.Context, repos *Repositories) error {
+			records := []entity.AuditRecord{
+				auditRecord("alice", "req-1", entity.AuditActionPRCreate, entity.AuditEntityPullRequest, "pr1", `null`, `{"status":"OPEN"}`),
+				auditRecord("bob", "req-2", entity.AuditActionPRMerge, entity.AuditEntityPullRequest, "pr1", `{"status":"OPEN"}`, `{"status":"MERGED"}`),
+			}
+			if err := repos.Audit.AddRecords(ctx, records); err != nil {
+				return err
+			}
+			later := []entity.AuditRecord{
+				auditRecord("alice", "req-3", entity.AuditActionSetIsActive, entity.AuditEntityUser, "u1", `{"is_active":true}`, `{"is_active":false}`),
+			}
+			if err := repos.Audit.AddRecords(ctx, later); err != nil {
+				return err
+			}
+			if records[0].ID == 0 || records[0].CreatedAt.IsZero() {
+				return fmt.Errorf("add records: id or created_at is not set")
+			}
+			if !(records[0].ID < records[1].ID && records[1].ID < later[0].ID) {
+				return fmt.Errorf("add records: ids are not increasing: %d, %d, %d", records[0].ID, records[1].ID, later[0].ID)
+			}
+
+			// Записи называются по порядку добавления: ID выдаются хранилищем
+			names := map[int64]string{records[0].ID: "r1", records[1].ID: "r2", later[0].ID: "r3"}
+			list := func(filter entity.AuditFilter) []string {
+				if filter.Limit == 0 {
+					filter.Limit = 10
+				}
+				found, err := repos.Audit.ListRecords(ctx, filter)
+				if err != nil {
+					return []string{err.Error()}
+				}
+				listed := make([]string, 0, len(found))
+				for _, record := range found {
+					listed = append(listed, names[record.ID])
+				}
+				return listed
+			}
+			all, err := repos.Audit.ListRecords(ctx, entity.AuditFilter{Limit: 10})
+			if err != nil {
+				return err
+			}
+			hourAgo := records[0].CreatedAt.Add(-time.Hour)
+			inHour := records[0].CreatedAt.Add(time.Hour)
+
+			return first(
+				expectEqual("all", auditValues(all), auditValues(append(later, records[1], records[0]))),
+				expectEqual("by actor", list(entity.AuditFilter{Actor: "alice"}), []string{"r3", "r1"}),
+				expectEqual("by request", list(entity.AuditFilter{RequestID: "req-2"}), []string{"r2"}),
+				expectEqual("by action", list(entity.AuditFilter{Action: entity.AuditActionPRCreate}), []string{"r1"}),
+				expectEqual("by entity", list(entity.AuditFilter{EntityType: entity.AuditEntityPullRequest, EntityID: "pr1"}), []string{"r2", "r1"}),
+				expectEqual("by entity id", list(entity.AuditFilter{EntityID: "u1"}), []string{"r3"}),
+				expectEqual("limit", list(entity.AuditFilter{Limit: 2}), []string{"r3", "r2"}),
+				expectEqual("before id", list(entity.AuditFilter{BeforeID: records[1].ID}), []string{"r1"}),
+				expectEqual("time range", list(entity.AuditFilter{From: &hourAgo, To: &inHour}), []string{"r3", "r2", "r1"}),
+				expectEqual("before range", list(entity.AuditFilter{To: &hourAgo}), []string{}),
+				expectEqual("after range", list(entity.AuditFilter{From: &inHour}), []string{}),
+			)
+		}},
+		{Name: "audit/delete expired", Run: func(ctx context.Context, repos *Repositories) error {
+			records := []entity.AuditRecord{
+				auditRecord("alice", "req-1", entity.AuditActionTeamAdd, entity.AuditEntityTeam, "backend", `null`, `{"team_name":"backend"}`),
+				auditRecord("alice", "req-1", entity.AuditActionTeamAdd, entity.AuditEntityUser, "u1", `null`, `{"user_id":"u1"}`),
+			}
+			if err := repos.Audit.AddRecords(ctx, records); err != nil {
+				return err
+			}
+
+			kept, err := repos.Audit.DeleteBefore(ctx, records[0].CreatedAt.Add(-time.Hour))
+			if err != nil {
+				return err
+			}
+			deleted, err := repos.Audit.DeleteBefore(ctx, records[0].CreatedAt.Add(time.Hour))
+			if err != nil {
+				return err
+			}
+			left, err := repos.Audit.ListRecords(ctx, entity.AuditFilter{Limit: 10})
+			if err != nil {
+				return err
+			}
+
+			return first(
+				expectEqual("deleted before records", kept, int64(0)),
+				expectEqual("deleted after records", deleted, int64(2)),
+				expectEqual("left", len(left), 0),
+			)
+		}},
+	}
+}
+
+func auditRecord(actor, requestID string, action entity.AuditAction, entityType entity.AuditEntityType, entityID, before, after string) entity.AuditRecord {
+	return entity.AuditRecord{
+		Action:     action,
+		EntityType: entityType,
+		EntityID:   entityID,
+		Actor:      actor,
+		RequestID:  requestID,
+		Before:     json.RawMessage(before),
+		After:      json.RawMessage(after),
+	}
+}
+
+// auditValues описывает записи без времени: хранилища сохраняют его с разной точностью
+func auditValues(records []entity.AuditRecord) []string {
+	values := make([]string, 0, len(records))
+	for _, record := range records {
+		values = append(values, fmt.Sprintf("%d %s %s %s %s/%s %s -> %s",
+			record.ID, record.Actor, record.RequestID, record.Action, record.EntityType, record.EntityID,
+			compactJSON(record.Before), compactJSON(record.After)))
+	}
+	return values
+}
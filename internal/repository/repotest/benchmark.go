@@ -0,0 +1,46 @@
+package repotest
+
+import (
+	"fmt"
+	"testing"
+)
+
+// reviewerPRCounts — размеры списка PR ревьювера в BenchmarkGetByReviewer
+var reviewerPRCounts = []int{10, 100, 300}
+
+// BenchmarkGetByReviewer замеряет чтение списка PR ревьювера, у которого открыто от 10 до 300 PR
+// с тремя ревьюверами. Ревьюверы читаются в том же запросе, что и PR, поэтому время растет
+// только с объемом данных, а не числом запросов
+func BenchmarkGetByReviewer(b *testing.B, newRepos Factory) {
+	for _, prCount := range reviewerPRCounts {
+		b.Run(fmt.Sprintf("prs=%d", prCount), func(b *testing.B) {
+			ctx := b.Context()
+			repos, cleanup, err := newRepos(ctx)
+			if err != nil {
+				b.Fatal(err)
+			}
+			defer cleanup()
+
+			if err := seedTeam(ctx, repos, testTeam, 4); err != nil {
+				b.Fatal(err)
+			}
+			reviewerID := userID(testTeam, 2)
+			for i := range prCount {
+				prID := fmt.Sprintf("bench_pr_%d", i)
+				if _, err := seedPullRequest(ctx, repos, prID, userID(testTeam, 1), reviewerID, userID(testTeam, 3), userID(testTeam, 4)); err != nil {
+					b.Fatal(err)
+				}
+			}
+
+			for b.Loop() {
+				prs, err := repos.PullRequest.GetByReviewer(ctx, reviewerID)
+				if err != nil {
+					b.Fatal(err)
+				}
+				if len(prs) != prCount {
+					b.Fatalf("expected %d prs, got %d", prCount, len(prs))
+				}
+			}
+		})
+	}
+}
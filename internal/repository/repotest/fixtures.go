@@ -0,0 +1,70 @@
+package repotest
+
+import (
+	"context"
+	"fmt"
+	"internship/internal/domain/entity"
+)
+
+const testTeam = "conformance"
+
+// seedTeam создает команду с активными пользователями u1..uN
+func seedTeam(ctx context.Context, repos *Repositories, teamName string, size int) error {
+	if err := repos.Team.Create(ctx, &entity.Team{TeamName: teamName, ReviewerSettings: entity.DefaultReviewerSettings}); err != nil {
+		return fmt.Errorf("create team: %w", err)
+	}
+
+	users := make([]*entity.User, 0, size)
+	for i := 1; i <= size; i++ {
+		users = append(users, &entity.User{
+			UserID:   fmt.Sprintf("%s_u%d", teamName, i),
+			Username: fmt.Sprintf("%s user %d", teamName, i),
+			TeamName: teamName,
+			IsActive: true,
+		})
+	}
+	if err := repos.User.BatchCreateOrUpdate(ctx, users); err != nil {
+		return fmt.Errorf("create users: %w", err)
+	}
+
+	return nil
+}
+
+// userID возвращает ID пользователя, созданного seedTeam
+func userID(teamName string, n int) string {
+	return fmt.Sprintf("%s_u%d", teamName, n)
+}
+
+// seedPullRequest создает открытый PR автора и назначает ревьюверов по порядку
+func seedPullRequest(ctx context.Context, repos *Repositories, prID, authorID string, reviewers ...string) (*entity.PullRequest, error) {
+	pr := &entity.PullRequest{
+		PullRequestID:   prID,
+		PullRequestName: "PR " + prID,
+		AuthorID:        authorID,
+		Status:          entity.PRStatusOpen,
+	}
+	if err := repos.PullRequest.Create(ctx, pr); err != nil {
+		return nil, fmt.Errorf("create pr %s: %w", prID, err)
+	}
+
+	for _, reviewerID := range reviewers {
+		if err := repos.Reviewer.AssignReviewer(ctx, prID, reviewerID); err != nil {
+			return nil, fmt.Errorf("assign reviewer %s to pr %s: %w", reviewerID, prID, err)
+		}
+	}
+
+	return pr, nil
+}
+
+// prIDs возвращает ID PR в порядке списка
+func prIDs(prs []entity.PullRequest) []string {
+	ids := make([]string, 0, len(prs))
+	for _, pr := range prs {
+		ids = append(ids, pr.PullRequestID)
+	}
+	return ids
+}
+
+func intPtr(v int) *int {
+	return &v
+}
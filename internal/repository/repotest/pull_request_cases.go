@@ -0,0 +1,261 @@
+package repotest
+
+import (
+	"context"
+	"internship/internal/domain/entity"
+	"time"
+)
+
+func pullRequestCases() []Case {
+	return []Case{
+		{Name: "pull request/not found", Run: func(ctx context.Context, repos *Repositories) error {
+			_, getErr := repos.PullRequest.GetByID(ctx, "missing")
+			_, getForUpdateErr := getForUpdate(ctx, repos, "missing")
+			updateErr := repos.PullRequest.Update(ctx, &entity.PullRequest{
+				PullRequestID: "missing",
+				Status:        entity.PRStatusOpen,
+				Version:       1,
+			})
+			exists, existsErr := repos.PullRequest.Exists(ctx, "missing")
+
+			return first(
+				expectErr("get missing pr", getErr, entity.ErrPRNotFound),
+				expectErr("get missing pr for update", getForUpdateErr, entity.ErrPRNotFound),
+				expectErr("update missing pr", updateErr, entity.ErrPRNotFound),
+				existsErr,
+				expectEqual("exists", exists, false),
+			)
+		}},
+		{Name: "pull request/create and get", Run: func(ctx context.Context, repos *Repositories) error {
+			if err := seedTeam(ctx, repos, testTeam, 3); err != nil {
+				return err
+			}
+			pr, err := seedPullRequest(ctx, repos, "pr1", userID(testTeam, 1), userID(testTeam, 2))
+			if err != nil {
+				return err
+			}
+
+			got, err := repos.PullRequest.GetByID(ctx, "pr1")
+			if err != nil {
+				return err
+			}
+			duplicateErr := repos.PullRequest.Create(ctx, &entity.PullRequest{
+				PullRequestID:   "pr1",
+				PullRequestName: "duplicate",
+				AuthorID:        userID(testTeam, 1),
+				Status:          entity.PRStatusOpen,
+			})
+
+			return first(
+				expectEqual("created version", pr.Version, 1),
+				expectEqual("name", got.PullRequestName, pr.PullRequestName),
+				expectEqual("author", got.AuthorID, pr.AuthorID),
+				expectEqual("status", got.Status, entity.PRStatusOpen),
+				expectEqual("created at is set", got.CreatedAt != nil, true),
+				expectEqual("merged at", got.MergedAt, (*time.Time)(nil)),
+				expectEqual("reviewers", got.AssignedReviewers, []string{userID(testTeam, 2)}),
+				expectErr("create duplicate pr", duplicateErr, entity.ErrPRExists),
+			)
+		}},
+		{Name: "pull request/changed files and required tags", Run: func(ctx context.Context, repos *Repositories) error {
+			if err := seedTeam(ctx, repos, testTeam, 2); err != nil {
+				return err
+			}
+			pr := &entity.PullRequest{
+				PullRequestID:   "pr1",
+				PullRequestName: "PR pr1",
+				ChangedFiles:    []string{"internal/service/team_service.go", "README.md"},
+				RequiredTags:    []string{"go", "sql"},
+				AuthorID:        userID(testTeam, 1),
+				Status:          entity.PRStatusOpen,
+			}
+			if err := repos.PullRequest.Create(ctx, pr); err != nil {
+				return err
+			}
+			if _, err := seedPullRequest(ctx, repos, "pr2", userID(testTeam, 1)); err != nil {
+				return err
+			}
+
+			// Правка PR не меняет измененные файлы и требуемые теги
+			pr.PullRequestName = "renamed"
+			if err := repos.PullRequest.Update(ctx, pr); err != nil {
+				return err
+			}
+			got, err := repos.PullRequest.GetByID(ctx, "pr1")
+			if err != nil {
+				return err
+			}
+			withoutFiles, err := repos.PullRequest.GetByID(ctx, "pr2")
+			if err != nil {
+				return err
+			}
+
+			return first(
+				expectEqual("changed files", got.ChangedFiles, []string{"internal/service/team_service.go", "README.md"}),
+				expectEqual("required tags", got.RequiredTags, []string{"go", "sql"}),
+				expectEqual("no changed files", withoutFiles.ChangedFiles, []string{}),
+				expectEqual("no required tags", withoutFiles.RequiredTags, []string{}),
+			)
+		}},
+		{Name: "pull request/update checks version", Run: func(ctx context.Context, repos *Repositories) error {
+			if err := seedTeam(ctx, repos, testTeam, 1); err != nil {
+				return err
+			}
+			if _, err := seedPullRequest(ctx, repos, "pr1", userID(testTeam, 1)); err != nil {
+				return err
+			}
+
+			pr, err := getForUpdate(ctx, repos, "pr1")
+			if err != nil {
+				return err
+			}
+			stale := *pr
+
+			mergedAt := time.Now()
+			pr.Status = entity.PRStatusMerged
+			pr.MergedAt = &mergedAt
+			if err := repos.PullRequest.Update(ctx, pr); err != nil {
+				return err
+			}
+			staleErr := repos.PullRequest.Update(ctx, &stale)
+
+			got, err := repos.PullRequest.GetByID(ctx, "pr1")
+			if err != nil {
+				return err
+			}
+
+			return first(
+				expectEqual("updated version", pr.Version, 2),
+				expectErr("update with stale version", staleErr, entity.ErrVersionConflict),
+				expectEqual("status", got.Status, entity.PRStatusMerged),
+				expectEqual("merged at is set", got.MergedAt != nil, true),
+				expectEqual("stored version", got.Version, 2),
+			)
+		}},
+		{Name: "pull request/draft and closed", Run: func(ctx context.Context, repos *Repositories) error {
+			if err := seedTeam(ctx, repos, testTeam, 2); err != nil {
+				return err
+			}
+			author, reviewer := userID(testTeam, 1), userID(testTeam, 2)
+			draft := &entity.PullRequest{
+				PullRequestID:   "pr1",
+				PullRequestName: "PR pr1",
+				AuthorID:        author,
+				Status:          entity.PRStatusDraft,
+			}
+			if err := repos.PullRequest.Create(ctx, draft); err != nil {
+				return err
+			}
+			closed, err := seedPullRequest(ctx, repos, "pr2", author, reviewer)
+			if err != nil {
+				return err
+			}
+			closed.Status = entity.PRStatusClosed
+			closed.NeedMoreReviewers = true
+			if err := repos.PullRequest.Update(ctx, closed); err != nil {
+				return err
+			}
+
+			gotDraft, err := repos.PullRequest.GetByID(ctx, "pr1")
+			if err != nil {
+				return err
+			}
+			gotClosed, err := repos.PullRequest.GetByID(ctx, "pr2")
+			if err != nil {
+				return err
+			}
+			open, err := repos.PullRequest.GetOpenPRsByReviewers(ctx, []string{reviewer})
+			if err != nil {
+				return err
+			}
+			needReviewers, err := repos.PullRequest.GetOpenNeedingReviewers(ctx)
+			if err != nil {
+				return err
+			}
+			loads, err := repos.Reviewer.CountOpenReviews(ctx, []string{reviewer})
+			if err != nil {
+				return err
+			}
+
+			// Назначения закрытого PR сохраняются, но не считаются открытыми ревью
+			return first(
+				expectEqual("draft status", gotDraft.Status, entity.PRStatusDraft),
+				expectEqual("closed status", gotClosed.Status, entity.PRStatusClosed),
+				expectEqual("closed reviewers", gotClosed.AssignedReviewers, []string{reviewer}),
+				expectEqual("open prs by reviewers", len(open), 0),
+				expectEqual("open prs needing reviewers", len(needReviewers), 0),
+				expectEqual("open reviews", loads[reviewer], 0),
+			)
+		}},
+		{Name: "pull request/lists", Run: func(ctx context.Context, repos *Repositories) error {
+			if err := seedTeam(ctx, repos, testTeam, 3); err != nil {
+				return err
+			}
+			author, reviewerA, reviewerB := userID(testTeam, 1), userID(testTeam, 2), userID(testTeam, 3)
+			if _, err := seedPullRequest(ctx, repos, "pr1", author, reviewerA, reviewerB); err != nil {
+				return err
+			}
+			if _, err := seedPullRequest(ctx, repos, "pr2", author, reviewerA); err != nil {
+				return err
+			}
+			merged, err := seedPullRequest(ctx, repos, "pr3", author, reviewerA, reviewerB)
+			if err != nil {
+				return err
+			}
+			merged.Status = entity.PRStatusMerged
+			if err := repos.PullRequest.Update(ctx, merged); err != nil {
+				return err
+			}
+			needing, err := seedPullRequest(ctx, repos, "pr4", author)
+			if err != nil {
+				return err
+			}
+			needing.NeedMoreReviewers = true
+			if err := repos.PullRequest.Update(ctx, needing); err != nil {
+				return err
+			}
+
+			byReviewer, err := repos.PullRequest.GetByReviewer(ctx, reviewerA)
+			if err != nil {
+				return err
+			}
+			open, err := repos.PullRequest.GetOpenPRsByReviewers(ctx, []string{reviewerA, reviewerB})
+			if err != nil {
+				return err
+			}
+			none, err := repos.PullRequest.GetOpenPRsByReviewers(ctx, nil)
+			if err != nil {
+				return err
+			}
+			needReviewers, err := repos.PullRequest.GetOpenNeedingReviewers(ctx)
+			if err != nil {
+				return err
+			}
+
+			var openReviewers [][]string
+			for _, pr := range open {
+				openReviewers = append(openReviewers, pr.AssignedReviewers)
+			}
+
+			// Списки отсортированы от новых PR к старым и содержат каждый PR один раз
+			return first(
+				expectEqual("prs by reviewer", prIDs(byReviewer), []string{"pr3", "pr2", "pr1"}),
+				expectEqual("open prs by reviewers", prIDs(open), []string{"pr2", "pr1"}),
+				expectEqual("open prs reviewers", openReviewers, [][]string{{reviewerA}, {reviewerA, reviewerB}}),
+				expectEqual("open prs by no reviewers", len(none), 0),
+				expectEqual("open prs needing reviewers", prIDs(needReviewers), []string{"pr4"}),
+			)
+		}},
+	}
+}
+
+// getForUpdate читает PR с блокировкой в транзакции, как это делают сервисы
+func getForUpdate(ctx context.Context, repos *Repositories, prID string) (*entity.PullRequest, error) {
+	var pr *entity.PullRequest
+	err := repos.TxManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		pr, err = repos.PullRequest.GetByIDForUpdate(ctx, prID)
+		return err
+	})
+	return pr, err
+}
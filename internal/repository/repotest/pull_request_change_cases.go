@@ -0,0 +1,131 @@
+package repotest
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"internship/internal/domain/entity"
+)
+
+func pullRequestChangeCases() []Case {
+	return []Case{
+		{Name: "pull request change/metadata round trip", Run: func(ctx context.Context, repos *Repositories) error {
+			if err := seedTeam(ctx, repos, testTeam, 2); err != nil {
+				return err
+			}
+			pr := &entity.PullRequest{
+				PullRequestID:   "pr1",
+				PullRequestName: "PR pr1",
+				Description:     "first version",
+				Labels:          []string{"backend", "bug"},
+				Metadata:        map[string]string{"ticket": "T-1"},
+				AuthorID:        userID(testTeam, 1),
+				Status:          entity.PRStatusOpen,
+			}
+			if err := repos.PullRequest.Create(ctx, pr); err != nil {
+				return err
+			}
+			if _, err := seedPullRequest(ctx, repos, "pr2", userID(testTeam, 1), userID(testTeam, 2)); err != nil {
+				return err
+			}
+
+			created, err := repos.PullRequest.GetByID(ctx, "pr1")
+			if err != nil {
+				return err
+			}
+			if err := first(
+				expectEqual("created description", created.Description, "first version"),
+				expectEqual("created labels", created.Labels, []string{"backend", "bug"}),
+				expectEqual("created metadata", created.Metadata, map[string]string{"ticket": "T-1"}),
+			); err != nil {
+				return err
+			}
+
+			created.Description = "second version"
+			created.Labels = []string{}
+			created.Metadata = map[string]string{"ticket": "T-2", "epic": "E-1"}
+			if err := repos.PullRequest.Update(ctx, created); err != nil {
+				return err
+			}
+			updated, err := repos.PullRequest.GetByID(ctx, "pr1")
+			if err != nil {
+				return err
+			}
+			// PR без меток и метаданных возвращает пустые значения, а не null
+			listed, err := repos.PullRequest.GetByReviewer(ctx, userID(testTeam, 2))
+			if err != nil {
+				return err
+			}
+
+			return first(
+				expectEqual("updated description", updated.Description, "second version"),
+				expectEqual("updated labels", updated.Labels, []string{}),
+				expectEqual("updated metadata", updated.Metadata, map[string]string{"ticket": "T-2", "epic": "E-1"}),
+				expectEqual("listed prs", prIDs(listed), []string{"pr2"}),
+				expectEqual("listed labels", listed[0].Labels, []string{}),
+				expectEqual("listed metadata", listed[0].Metadata, map[string]string{}),
+			)
+		}},
+		{Name: "pull request change/history in order", Run: func(ctx context.Context, repos *Repositories) error {
+			if err := seedTeam(ctx, repos, testTeam, 1); err != nil {
+				return err
+			}
+			if _, err := seedPullRequest(ctx, repos, "pr1", userID(testTeam, 1)); err != nil {
+				return err
+			}
+			if _, err := seedPullRequest(ctx, repos, "pr2", userID(testTeam, 1)); err != nil {
+				return err
+			}
+
+			firstEdit := []entity.PullRequestChange{
+				{Field: entity.PRFieldName, OldValue: json.RawMessage(`"PR pr1"`), NewValue: json.RawMessage(`"Renamed"`)},
+				{Field: entity.PRFieldLabels, OldValue: json.RawMessage(`[]`), NewValue: json.RawMessage(`["bug"]`)},
+			}
+			secondEdit := []entity.PullRequestChange{
+				{Field: entity.PRFieldMetadata, OldValue: json.RawMessage(`{}`), NewValue: json.RawMessage(`{"ticket":"T-1"}`)},
+			}
+			if err := repos.Change.AddChanges(ctx, "pr1", firstEdit); err != nil {
+				return err
+			}
+			if err := repos.Change.AddChanges(ctx, "pr1", secondEdit); err != nil {
+				return err
+			}
+			if firstEdit[0].ChangedAt.IsZero() {
+				return fmt.Errorf("add changes: changed_at is not set")
+			}
+
+			changes, err := repos.Change.GetChanges(ctx, "pr1")
+			if err != nil {
+				return err
+			}
+			empty, err := repos.Change.GetChanges(ctx, "pr2")
+			if err != nil {
+				return err
+			}
+
+			return first(
+				expectEqual("changes", changeValues(changes), changeValues(append(firstEdit, secondEdit...))),
+				expectEqual("changes of unchanged pr", len(empty), 0),
+			)
+		}},
+	}
+}
+
+// changeValues описывает изменения без времени: хранилища сохраняют его с разной точностью,
+// а JSON значений может быть по-разному отформатирован
+func changeValues(changes []entity.PullRequestChange) []string {
+	values := make([]string, 0, len(changes))
+	for _, change := range changes {
+		values = append(values, fmt.Sprintf("%s: %s -> %s", change.Field, compactJSON(change.OldValue), compactJSON(change.NewValue)))
+	}
+	return values
+}
+
+func compactJSON(raw json.RawMessage) string {
+	var value any
+	if err := json.Unmarshal(raw, &value); err != nil {
+		return "invalid json: " + string(raw)
+	}
+	compact, _ := json.Marshal(value)
+	return string(compact)
+}
@@ -0,0 +1,102 @@
+// Package repotest содержит набор проверок, общий для всех реализаций репозиториев.
+// Новое хранилище считается поведенчески эквивалентным PostgreSQL, если проходит все проверки.
+// Каждая реализация вызывает Run из своего repository_test.go, поэтому набор выполняется в go test
+package repotest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"internship/internal/service"
+	"reflect"
+	"testing"
+)
+
+// Repositories — набор репозиториев проверяемого хранилища
+type Repositories struct {
+	Team            service.TeamRepositoryInterface
+	User            service.UserRepositoryInterface
+	PullRequest     service.PullRequestRepositoryInterface
+	Reviewer        service.ReviewerRepositoryInterface
+	Review          service.ReviewDecisionRepositoryInterface
+	Change          service.PullRequestChangeRepositoryInterface
+	AssignmentEvent service.AssignmentEventRepositoryInterface
+	Audit           service.AuditRepositoryInterface
+	Statistics      service.StatisticsRepositoryInterface
+	TxManager       service.TransactionManager
+}
+
+// Factory создает репозитории над пустым хранилищем. Функция cleanup вызывается после проверки
+type Factory func(ctx context.Context) (repos *Repositories, cleanup func(), err error)
+
+// Case — отдельная проверка набора
+type Case struct {
+	Name string
+	Run  func(ctx context.Context, repos *Repositories) error
+}
+
+// Cases возвращает все проверки набора
+func Cases() []Case {
+	var cases []Case
+	cases = append(cases, teamCases()...)
+	cases = append(cases, userCases()...)
+	cases = append(cases, pullRequestCases()...)
+	cases = append(cases, reviewerCases()...)
+	cases = append(cases, reviewDecisionCases()...)
+	cases = append(cases, pullRequestChangeCases()...)
+	cases = append(cases, assignmentEventCases()...)
+	cases = append(cases, auditCases()...)
+	cases = append(cases, statisticsCases()...)
+	cases = append(cases, transactionCases()...)
+	return cases
+}
+
+// Run выполняет все проверки как подтесты t, каждую над новым хранилищем
+func Run(t *testing.T, newRepos Factory) {
+	t.Helper()
+
+	for _, c := range Cases() {
+		t.Run(c.Name, func(t *testing.T) {
+			if err := runCase(t.Context(), newRepos, c); err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
+
+// runCase выполняет одну проверку над новым хранилищем
+func runCase(ctx context.Context, newRepos Factory, c Case) error {
+	repos, cleanup, err := newRepos(ctx)
+	if err != nil {
+		return fmt.Errorf("create repositories: %w", err)
+	}
+	defer cleanup()
+
+	return c.Run(ctx, repos)
+}
+
+// expectErr проверяет, что операция вернула ожидаемую ошибку
+func expectErr(op string, err, target error) error {
+	if !errors.Is(err, target) {
+		return fmt.Errorf("%s: expected error %q, got %v", op, target, err)
+	}
+	return nil
+}
+
+// expectEqual проверяет равенство полученного и ожидаемого значений
+func expectEqual(op string, got, want any) error {
+	if !reflect.DeepEqual(got, want) {
+		return fmt.Errorf("%s: expected %v, got %v", op, want, got)
+	}
+	return nil
+}
+
+// first возвращает первую ненулевую ошибку
+func first(errs ...error) error {
+	for _, err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
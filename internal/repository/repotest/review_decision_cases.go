@@ -0,0 +1,79 @@
+package repotest
+
+import (
+	"context"
+	"fmt"
+	"internship/internal/domain/entity"
+	"time"
+)
+
+func reviewDecisionCases() []Case {
+	return []Case{
+		{Name: "review decision/history in order", Run: func(ctx context.Context, repos *Repositories) error {
+			if err := seedTeam(ctx, repos, testTeam, 3); err != nil {
+				return err
+			}
+			reviewerA, reviewerB := userID(testTeam, 2), userID(testTeam, 3)
+			if _, err := seedPullRequest(ctx, repos, "pr1", userID(testTeam, 1), reviewerA, reviewerB); err != nil {
+				return err
+			}
+			if _, err := seedPullRequest(ctx, repos, "pr2", userID(testTeam, 1), reviewerA); err != nil {
+				return err
+			}
+
+			// Повторное решение того же ревьювера добавляется в историю, а не заменяет прежнее
+			submitted := []entity.ReviewDecision{
+				{ReviewerID: reviewerA, Decision: entity.ReviewChangesRequested, Comment: "fix tests"},
+				{ReviewerID: reviewerB, Decision: entity.ReviewCommented},
+				{ReviewerID: reviewerA, Decision: entity.ReviewApproved},
+			}
+			for i := range submitted {
+				if err := repos.Review.AddDecision(ctx, "pr1", &submitted[i]); err != nil {
+					return err
+				}
+				if submitted[i].CreatedAt.IsZero() {
+					return fmt.Errorf("add decision: created_at is not set")
+				}
+			}
+
+			decisions, err := repos.Review.GetDecisions(ctx, "pr1")
+			if err != nil {
+				return err
+			}
+			empty, err := repos.Review.GetDecisions(ctx, "pr2")
+			if err != nil {
+				return err
+			}
+			pr, err := repos.PullRequest.GetByID(ctx, "pr1")
+			if err != nil {
+				return err
+			}
+			prs, err := repos.PullRequest.GetByReviewer(ctx, reviewerA)
+			if err != nil {
+				return err
+			}
+			listed := make(map[string][]entity.ReviewDecision, len(prs))
+			for _, pr := range prs {
+				listed[pr.PullRequestID] = withoutTime(pr.Reviews)
+			}
+
+			return first(
+				expectEqual("decisions", withoutTime(decisions), withoutTime(submitted)),
+				expectEqual("decisions of pr without reviews", len(empty), 0),
+				expectEqual("pr reviews", withoutTime(pr.Reviews), withoutTime(submitted)),
+				expectEqual("listed pr reviews", listed["pr1"], withoutTime(submitted)),
+				expectEqual("listed pr without reviews", listed["pr2"], []entity.ReviewDecision{}),
+			)
+		}},
+	}
+}
+
+// withoutTime обнуляет время решений: хранилища сохраняют его с разной точностью
+func withoutTime(decisions []entity.ReviewDecision) []entity.ReviewDecision {
+	result := make([]entity.ReviewDecision, 0, len(decisions))
+	for _, decision := range decisions {
+		decision.CreatedAt = time.Time{}
+		result = append(result, decision)
+	}
+	return result
+}
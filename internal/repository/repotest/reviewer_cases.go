@@ -0,0 +1,118 @@
+package repotest
+
+import (
+	"context"
+	"internship/internal/domain/entity"
+)
+
+func reviewerCases() []Case {
+	return []Case{
+		{Name: "reviewer/ordered by assigned_at", Run: func(ctx context.Context, repos *Repositories) error {
+			if err := seedTeam(ctx, repos, testTeam, 5); err != nil {
+				return err
+			}
+			// Ревьюверы назначаются не в порядке user_id, чтобы порядок назначения был различим
+			_, err := seedPullRequest(ctx, repos, "pr1", userID(testTeam, 1),
+				userID(testTeam, 4), userID(testTeam, 2), userID(testTeam, 3))
+			if err != nil {
+				return err
+			}
+
+			reviewers, err := repos.Reviewer.GetReviewers(ctx, "pr1")
+			if err != nil {
+				return err
+			}
+			if err := expectEqual("reviewers", reviewers, []string{userID(testTeam, 4), userID(testTeam, 2), userID(testTeam, 3)}); err != nil {
+				return err
+			}
+
+			// Замененный ревьювер назначен позже всех и оказывается в конце списка
+			if err := repos.Reviewer.ReplaceReviewer(ctx, "pr1", userID(testTeam, 4), userID(testTeam, 5)); err != nil {
+				return err
+			}
+			pr, err := repos.PullRequest.GetByID(ctx, "pr1")
+			if err != nil {
+				return err
+			}
+
+			return expectEqual("reviewers after replace", pr.AssignedReviewers,
+				[]string{userID(testTeam, 2), userID(testTeam, 3), userID(testTeam, 5)})
+		}},
+		{Name: "reviewer/assign, check and remove", Run: func(ctx context.Context, repos *Repositories) error {
+			if err := seedTeam(ctx, repos, testTeam, 3); err != nil {
+				return err
+			}
+			if _, err := seedPullRequest(ctx, repos, "pr1", userID(testTeam, 1), userID(testTeam, 2)); err != nil {
+				return err
+			}
+
+			// Повторное назначение не создает дубликат
+			if err := repos.Reviewer.AssignReviewer(ctx, "pr1", userID(testTeam, 2)); err != nil {
+				return err
+			}
+			duplicated, err := repos.Reviewer.GetReviewers(ctx, "pr1")
+			if err != nil {
+				return err
+			}
+
+			assigned, err := repos.Reviewer.IsAssigned(ctx, "pr1", userID(testTeam, 2))
+			if err != nil {
+				return err
+			}
+			notAssigned, err := repos.Reviewer.IsAssigned(ctx, "pr1", userID(testTeam, 3))
+			if err != nil {
+				return err
+			}
+
+			if err := repos.Reviewer.RemoveReviewer(ctx, "pr1", userID(testTeam, 2)); err != nil {
+				return err
+			}
+			removed, err := repos.Reviewer.GetReviewers(ctx, "pr1")
+			if err != nil {
+				return err
+			}
+
+			return first(
+				expectEqual("reviewers after duplicate assign", duplicated, []string{userID(testTeam, 2)}),
+				expectEqual("is assigned", assigned, true),
+				expectEqual("is not assigned", notAssigned, false),
+				expectEqual("reviewers after remove", len(removed), 0),
+			)
+		}},
+		{Name: "reviewer/count open reviews", Run: func(ctx context.Context, repos *Repositories) error {
+			if err := seedTeam(ctx, repos, testTeam, 3); err != nil {
+				return err
+			}
+			author, busy, idle := userID(testTeam, 1), userID(testTeam, 2), userID(testTeam, 3)
+			if _, err := seedPullRequest(ctx, repos, "pr1", author, busy); err != nil {
+				return err
+			}
+			if _, err := seedPullRequest(ctx, repos, "pr2", author, busy); err != nil {
+				return err
+			}
+			merged, err := seedPullRequest(ctx, repos, "pr3", author, busy, idle)
+			if err != nil {
+				return err
+			}
+			merged.Status = entity.PRStatusMerged
+			if err := repos.PullRequest.Update(ctx, merged); err != nil {
+				return err
+			}
+
+			counts, err := repos.Reviewer.CountOpenReviews(ctx, []string{busy, idle})
+			if err != nil {
+				return err
+			}
+			empty, err := repos.Reviewer.CountOpenReviews(ctx, nil)
+			if err != nil {
+				return err
+			}
+
+			// Учитываются только открытые PR, пользователи без ревью в ответ не попадают
+			return first(
+				expectEqual("counts", counts, map[string]int{busy: 2}),
+				expectEqual("counts for no users", len(empty), 0),
+			)
+		}},
+	}
+}
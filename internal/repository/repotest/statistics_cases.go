@@ -0,0 +1,61 @@
+package repotest
+
+import (
+	"context"
+	"fmt"
+	"internship/internal/domain/entity"
+)
+
+func statisticsCases() []Case {
+	return []Case{
+		{Name: "statistics/assignments and prs", Run: func(ctx context.Context, repos *Repositories) error {
+			if err := seedTeam(ctx, repos, testTeam, 3); err != nil {
+				return err
+			}
+			if _, err := seedPullRequest(ctx, repos, "pr1", userID(testTeam, 1), userID(testTeam, 2)); err != nil {
+				return err
+			}
+			merged, err := seedPullRequest(ctx, repos, "pr2", userID(testTeam, 1), userID(testTeam, 2))
+			if err != nil {
+				return err
+			}
+			merged.Status = entity.PRStatusMerged
+			if err := repos.PullRequest.Update(ctx, merged); err != nil {
+				return err
+			}
+			closed, err := seedPullRequest(ctx, repos, "pr3", userID(testTeam, 1))
+			if err != nil {
+				return err
+			}
+			closed.Status = entity.PRStatusClosed
+			if err := repos.PullRequest.Update(ctx, closed); err != nil {
+				return err
+			}
+
+			assignments, err := repos.Statistics.GetAssignmentStats(ctx)
+			if err != nil {
+				return err
+			}
+			prs, err := repos.Statistics.GetPRStats(ctx)
+			if err != nil {
+				return err
+			}
+
+			// Статистика назначений учитывает все PR и содержит пользователей без назначений
+			return first(
+				expectEqual("assignment stats", assignments, map[string]int{
+					fmt.Sprintf("%s user 1", testTeam): 0,
+					fmt.Sprintf("%s user 2", testTeam): 2,
+					fmt.Sprintf("%s user 3", testTeam): 0,
+				}),
+				expectEqual("pr stats", prs, map[string]interface{}{
+					"total_prs":  3,
+					"open_prs":   1,
+					"merged_prs": 1,
+					"draft_prs":  0,
+					"closed_prs": 1,
+				}),
+			)
+		}},
+	}
+}
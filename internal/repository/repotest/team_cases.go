@@ -0,0 +1,146 @@
+package repotest
+
+import (
+	"context"
+	"internship/internal/domain/entity"
+)
+
+func teamCases() []Case {
+	return []Case{
+		{Name: "team/create and get", Run: func(ctx context.Context, repos *Repositories) error {
+			team := &entity.Team{
+				TeamName:              testTeam,
+				DefaultMaxOpenReviews: intPtr(3),
+				ReviewerSettings:      entity.ReviewerSettings{MinReviewers: 1, MaxReviewers: 3},
+			}
+			if err := repos.Team.Create(ctx, team); err != nil {
+				return err
+			}
+
+			got, err := repos.Team.GetByName(ctx, testTeam)
+			if err != nil {
+				return err
+			}
+			exists, err := repos.Team.Exists(ctx, testTeam)
+			if err != nil {
+				return err
+			}
+
+			return first(
+				expectEqual("team name", got.TeamName, testTeam),
+				expectEqual("default max open reviews", got.DefaultMaxOpenReviews, intPtr(3)),
+				expectEqual("reviewer settings", got.ReviewerSettings, entity.ReviewerSettings{MinReviewers: 1, MaxReviewers: 3}),
+				expectEqual("exists", exists, true),
+			)
+		}},
+		{Name: "team/not found", Run: func(ctx context.Context, repos *Repositories) error {
+			_, err := repos.Team.GetByName(ctx, "missing")
+			exists, existsErr := repos.Team.Exists(ctx, "missing")
+
+			return first(
+				expectErr("get missing team", err, entity.ErrTeamNotFound),
+				existsErr,
+				expectEqual("exists", exists, false),
+			)
+		}},
+		{Name: "team/update reviewer settings", Run: func(ctx context.Context, repos *Repositories) error {
+			if err := repos.Team.Create(ctx, &entity.Team{TeamName: testTeam, ReviewerSettings: entity.DefaultReviewerSettings}); err != nil {
+				return err
+			}
+
+			settings := entity.ReviewerSettings{MinReviewers: 2, MaxReviewers: 4}
+			if err := repos.Team.UpdateReviewerSettings(ctx, testTeam, settings); err != nil {
+				return err
+			}
+			got, err := repos.Team.GetByName(ctx, testTeam)
+			if err != nil {
+				return err
+			}
+			missingErr := repos.Team.UpdateReviewerSettings(ctx, "missing", settings)
+
+			return first(
+				expectEqual("reviewer settings", got.ReviewerSettings, settings),
+				expectErr("update missing team", missingErr, entity.ErrTeamNotFound),
+			)
+		}},
+		{Name: "team/fallback teams", Run: func(ctx context.Context, repos *Repositories) error {
+			for _, teamName := range []string{testTeam, "first", "second"} {
+				if err := repos.Team.Create(ctx, &entity.Team{TeamName: teamName, ReviewerSettings: entity.DefaultReviewerSettings}); err != nil {
+					return err
+				}
+			}
+
+			if err := repos.Team.SetFallbackTeams(ctx, testTeam, []string{"second", "first"}); err != nil {
+				return err
+			}
+			ordered, err := repos.Team.GetFallbackTeams(ctx, testTeam)
+			if err != nil {
+				return err
+			}
+
+			if err := repos.Team.SetFallbackTeams(ctx, testTeam, []string{"first"}); err != nil {
+				return err
+			}
+			replaced, err := repos.Team.GetFallbackTeams(ctx, testTeam)
+			if err != nil {
+				return err
+			}
+			none, err := repos.Team.GetFallbackTeams(ctx, "first")
+			if err != nil {
+				return err
+			}
+
+			return first(
+				expectEqual("ordered fallback teams", ordered, []string{"second", "first"}),
+				expectEqual("replaced fallback teams", replaced, []string{"first"}),
+				expectEqual("no fallback teams", none, []string{}),
+			)
+		}},
+		{Name: "team/code owner rules", Run: func(ctx context.Context, repos *Repositories) error {
+			if err := seedTeam(ctx, repos, testTeam, 2); err != nil {
+				return err
+			}
+
+			rules := []entity.CodeOwnerRule{
+				{Pattern: "*", Owners: []string{userID(testTeam, 1)}},
+				{Pattern: "/internal/service/", Owners: []string{userID(testTeam, 2), userID(testTeam, 1)}},
+			}
+			if err := repos.Team.SetCodeOwnerRules(ctx, testTeam, rules); err != nil {
+				return err
+			}
+			ordered, err := repos.Team.GetCodeOwnerRules(ctx, testTeam)
+			if err != nil {
+				return err
+			}
+
+			if err := repos.Team.SetCodeOwnerRules(ctx, testTeam, rules[1:]); err != nil {
+				return err
+			}
+			replaced, err := repos.Team.GetCodeOwnerRules(ctx, testTeam)
+			if err != nil {
+				return err
+			}
+			if err := repos.Team.SetCodeOwnerRules(ctx, testTeam, nil); err != nil {
+				return err
+			}
+			cleared, err := repos.Team.GetCodeOwnerRules(ctx, testTeam)
+			if err != nil {
+				return err
+			}
+
+			return first(
+				expectEqual("ordered rules", ordered, rules),
+				expectEqual("replaced rules", replaced, rules[1:]),
+				expectEqual("cleared rules", cleared, []entity.CodeOwnerRule{}),
+			)
+		}},
+		{Name: "team/duplicate", Run: func(ctx context.Context, repos *Repositories) error {
+			if err := repos.Team.Create(ctx, &entity.Team{TeamName: testTeam, ReviewerSettings: entity.DefaultReviewerSettings}); err != nil {
+				return err
+			}
+
+			err := repos.Team.Create(ctx, &entity.Team{TeamName: testTeam, ReviewerSettings: entity.DefaultReviewerSettings})
+			return expectErr("create duplicate team", err, entity.ErrTeamExists)
+		}},
+	}
+}
@@ -0,0 +1,57 @@
+package repotest
+
+import (
+	"context"
+	"errors"
+	"internship/internal/domain/entity"
+)
+
+func transactionCases() []Case {
+	return []Case{
+		{Name: "transaction/rollback on error", Run: func(ctx context.Context, repos *Repositories) error {
+			errAbort := errors.New("abort")
+			err := repos.TxManager.WithinTransaction(ctx, func(ctx context.Context) error {
+				if err := seedTeam(ctx, repos, testTeam, 2); err != nil {
+					return err
+				}
+				if _, err := seedPullRequest(ctx, repos, "pr1", userID(testTeam, 1), userID(testTeam, 2)); err != nil {
+					return err
+				}
+				return errAbort
+			})
+			if err := expectErr("transaction", err, errAbort); err != nil {
+				return err
+			}
+
+			teamExists, err := repos.Team.Exists(ctx, testTeam)
+			if err != nil {
+				return err
+			}
+			_, userErr := repos.User.GetByID(ctx, userID(testTeam, 1))
+			prExists, err := repos.PullRequest.Exists(ctx, "pr1")
+			if err != nil {
+				return err
+			}
+
+			return first(
+				expectEqual("team exists", teamExists, false),
+				expectErr("get rolled back user", userErr, entity.ErrUserNotFound),
+				expectEqual("pr exists", prExists, false),
+			)
+		}},
+		{Name: "transaction/commit", Run: func(ctx context.Context, repos *Repositories) error {
+			err := repos.TxManager.WithinTransaction(ctx, func(ctx context.Context) error {
+				// Вложенная транзакция выполняется во внешней
+				return repos.TxManager.WithinTransaction(ctx, func(ctx context.Context) error {
+					return seedTeam(ctx, repos, testTeam, 1)
+				})
+			})
+			if err != nil {
+				return err
+			}
+
+			_, err = repos.User.GetByID(ctx, userID(testTeam, 1))
+			return err
+		}},
+	}
+}
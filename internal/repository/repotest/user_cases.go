@@ -0,0 +1,193 @@
+package repotest
+
+import (
+	"context"
+	"internship/internal/domain/entity"
+)
+
+func userCases() []Case {
+	return []Case{
+		{Name: "user/not found", Run: func(ctx context.Context, repos *Repositories) error {
+			if err := repos.Team.Create(ctx, &entity.Team{TeamName: testTeam, ReviewerSettings: entity.DefaultReviewerSettings}); err != nil {
+				return err
+			}
+
+			_, getErr := repos.User.GetByID(ctx, "missing")
+			updateErr := repos.User.Update(ctx, &entity.User{UserID: "missing", Username: "x", TeamName: testTeam})
+			setActiveErr := repos.User.SetIsActive(ctx, "missing", false)
+
+			return first(
+				expectErr("get missing user", getErr, entity.ErrUserNotFound),
+				expectErr("update missing user", updateErr, entity.ErrUserNotFound),
+				expectErr("set is_active of missing user", setActiveErr, entity.ErrUserNotFound),
+			)
+		}},
+		{Name: "user/batch create or update upserts", Run: func(ctx context.Context, repos *Repositories) error {
+			if err := seedTeam(ctx, repos, testTeam, 2); err != nil {
+				return err
+			}
+			if err := repos.Team.Create(ctx, &entity.Team{TeamName: "other", ReviewerSettings: entity.DefaultReviewerSettings}); err != nil {
+				return err
+			}
+
+			// Повторный вызов обновляет существующих пользователей и добавляет новых
+			err := repos.User.BatchCreateOrUpdate(ctx, []*entity.User{
+				{UserID: userID(testTeam, 1), Username: "renamed", TeamName: testTeam, IsActive: false, MaxOpenReviews: intPtr(5), ExpertiseTags: []string{"go", "sql"}},
+				{UserID: userID(testTeam, 2), Username: "moved", TeamName: "other", IsActive: true},
+				{UserID: userID(testTeam, 3), Username: "new", TeamName: testTeam, IsActive: true},
+			})
+			if err != nil {
+				return err
+			}
+
+			updated, err := repos.User.GetByID(ctx, userID(testTeam, 1))
+			if err != nil {
+				return err
+			}
+			members, err := repos.User.GetByTeamName(ctx, testTeam)
+			if err != nil {
+				return err
+			}
+			others, err := repos.User.GetByTeamName(ctx, "other")
+			if err != nil {
+				return err
+			}
+
+			return first(
+				expectEqual("updated user", *updated, entity.User{
+					UserID:         userID(testTeam, 1),
+					Username:       "renamed",
+					TeamName:       testTeam,
+					IsActive:       false,
+					MaxOpenReviews: intPtr(5),
+					ExpertiseTags:  []string{"go", "sql"},
+				}),
+				expectEqual("team members", len(members), 2),
+				expectEqual("moved user team", len(others), 1),
+			)
+		}},
+		{Name: "user/update and set is_active", Run: func(ctx context.Context, repos *Repositories) error {
+			if err := seedTeam(ctx, repos, testTeam, 1); err != nil {
+				return err
+			}
+
+			id := userID(testTeam, 1)
+			if err := repos.User.Update(ctx, &entity.User{UserID: id, Username: "updated", TeamName: testTeam, IsActive: true}); err != nil {
+				return err
+			}
+			if err := repos.User.SetIsActive(ctx, id, false); err != nil {
+				return err
+			}
+
+			user, err := repos.User.GetByID(ctx, id)
+			if err != nil {
+				return err
+			}
+
+			return first(
+				expectEqual("username", user.Username, "updated"),
+				expectEqual("is_active", user.IsActive, false),
+			)
+		}},
+		{Name: "user/set expertise tags", Run: func(ctx context.Context, repos *Repositories) error {
+			if err := seedTeam(ctx, repos, testTeam, 2); err != nil {
+				return err
+			}
+
+			id := userID(testTeam, 1)
+			if err := repos.User.SetExpertiseTags(ctx, id, []string{"frontend", "go"}); err != nil {
+				return err
+			}
+			tagged, err := repos.User.GetByID(ctx, id)
+			if err != nil {
+				return err
+			}
+			if err := repos.User.SetExpertiseTags(ctx, id, []string{}); err != nil {
+				return err
+			}
+			cleared, err := repos.User.GetByID(ctx, id)
+			if err != nil {
+				return err
+			}
+			members, err := repos.User.GetByTeamName(ctx, testTeam)
+			if err != nil {
+				return err
+			}
+			missingErr := repos.User.SetExpertiseTags(ctx, "missing", []string{"go"})
+
+			return first(
+				expectEqual("expertise tags", tagged.ExpertiseTags, []string{"frontend", "go"}),
+				expectEqual("cleared tags", cleared.ExpertiseTags, []string{}),
+				expectEqual("untagged member", members[1].ExpertiseTags, []string{}),
+				expectErr("set tags of missing user", missingErr, entity.ErrUserNotFound),
+			)
+		}},
+		{Name: "user/deactivate team members", Run: func(ctx context.Context, repos *Repositories) error {
+			if err := seedTeam(ctx, repos, testTeam, 4); err != nil {
+				return err
+			}
+			pr, err := seedPullRequest(ctx, repos, "pr1", userID(testTeam, 1), userID(testTeam, 2), userID(testTeam, 3))
+			if err != nil {
+				return err
+			}
+
+			reassignments := []entity.ReviewerReassignment{{
+				PullRequest:      entity.PullRequest{PullRequestID: pr.PullRequestID, Version: pr.Version, NeedMoreReviewers: true},
+				RemovedReviewers: []string{userID(testTeam, 2)},
+				AddedReviewers:   []string{userID(testTeam, 4)},
+			}}
+			if err := repos.User.DeactivateTeamMembers(ctx, testTeam, reassignments); err != nil {
+				return err
+			}
+
+			got, err := repos.PullRequest.GetByID(ctx, pr.PullRequestID)
+			if err != nil {
+				return err
+			}
+			user, err := repos.User.GetByID(ctx, userID(testTeam, 1))
+			if err != nil {
+				return err
+			}
+
+			return first(
+				expectEqual("is_active", user.IsActive, false),
+				expectEqual("reviewers", got.AssignedReviewers, []string{userID(testTeam, 3), userID(testTeam, 4)}),
+				expectEqual("need more reviewers", got.NeedMoreReviewers, true),
+				expectEqual("version", got.Version, pr.Version+1),
+				expectEqual("reassignment version", reassignments[0].PullRequest.Version, got.Version),
+			)
+		}},
+		{Name: "user/deactivate team members rejects stale version", Run: func(ctx context.Context, repos *Repositories) error {
+			if err := seedTeam(ctx, repos, testTeam, 3); err != nil {
+				return err
+			}
+			pr, err := seedPullRequest(ctx, repos, "pr1", userID(testTeam, 1), userID(testTeam, 2))
+			if err != nil {
+				return err
+			}
+
+			err = repos.User.DeactivateTeamMembers(ctx, testTeam, []entity.ReviewerReassignment{{
+				PullRequest:      entity.PullRequest{PullRequestID: pr.PullRequestID, Version: pr.Version + 1},
+				RemovedReviewers: []string{userID(testTeam, 2)},
+			}})
+			if err := expectErr("deactivate with stale version", err, entity.ErrVersionConflict); err != nil {
+				return err
+			}
+
+			// Деактивация откатывается целиком
+			user, err := repos.User.GetByID(ctx, userID(testTeam, 1))
+			if err != nil {
+				return err
+			}
+			reviewers, err := repos.Reviewer.GetReviewers(ctx, pr.PullRequestID)
+			if err != nil {
+				return err
+			}
+
+			return first(
+				expectEqual("is_active", user.IsActive, true),
+				expectEqual("reviewers", reviewers, []string{userID(testTeam, 2)}),
+			)
+		}},
+	}
+}
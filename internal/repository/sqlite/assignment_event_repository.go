@@ -0,0 +1,95 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"internship/internal/domain/entity"
+)
+
+const (
+	queryAddAssignmentEvent = `
+		INSERT INTO reviewer_assignment_events (pull_request_id, event_type, user_id, previous_user_id, reason, actor, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?)
+	`
+	queryGetAssignmentEvents = `
+		SELECT pull_request_id, event_type, user_id, previous_user_id, reason, actor, created_at
+		FROM reviewer_assignment_events
+		WHERE pull_request_id = ?
+		ORDER BY id
+	`
+)
+
+type AssignmentEventRepository struct {
+	db *sql.DB
+}
+
+func NewAssignmentEventRepository(db *sql.DB) *AssignmentEventRepository {
+	return &AssignmentEventRepository{db: db}
+}
+
+// AddEvents дописывает события назначения ревьюверов в историю PR
+func (r *AssignmentEventRepository) AddEvents(ctx context.Context, events []entity.ReviewerAssignmentEvent) error {
+	if len(events) == 0 {
+		return nil
+	}
+
+	// Запрос подготавливается один раз на всю пачку событий
+	stmt, err := conn(ctx, r.db).PrepareContext(ctx, queryAddAssignmentEvent)
+	if err != nil {
+		return fmt.Errorf("prepare reviewer assignment event: %w", err)
+	}
+	defer stmt.Close()
+
+	createdAt := now()
+	for i := range events {
+		_, err := stmt.ExecContext(ctx,
+			events[i].PullRequestID,
+			events[i].Event,
+			events[i].UserID,
+			events[i].PreviousUserID,
+			events[i].Reason,
+			events[i].Actor,
+			createdAt,
+		)
+		if err != nil {
+			return fmt.Errorf("add reviewer assignment event: %w", err)
+		}
+		events[i].CreatedAt = createdAt
+	}
+
+	return nil
+}
+
+// GetEvents получает историю назначений ревьюверов PR в порядке записи
+func (r *AssignmentEventRepository) GetEvents(ctx context.Context, prID string) ([]entity.ReviewerAssignmentEvent, error) {
+	rows, err := conn(ctx, r.db).QueryContext(ctx, queryGetAssignmentEvents, prID)
+	if err != nil {
+		return nil, fmt.Errorf("get reviewer assignment events: %w", err)
+	}
+	defer rows.Close()
+
+	events := []entity.ReviewerAssignmentEvent{}
+	for rows.Next() {
+		var event entity.ReviewerAssignmentEvent
+		err := rows.Scan(
+			&event.PullRequestID,
+			&event.Event,
+			&event.UserID,
+			&event.PreviousUserID,
+			&event.Reason,
+			&event.Actor,
+			&event.CreatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("scan reviewer assignment event: %w", err)
+		}
+		events = append(events, event)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate reviewer assignment events: %w", err)
+	}
+
+	return events, nil
+}
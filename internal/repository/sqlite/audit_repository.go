@@ -0,0 +1,153 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"internship/internal/domain/entity"
+	"time"
+)
+
+const (
+	queryAddAuditRecord = `
+		INSERT INTO audit_log (action, entity_type, entity_id, actor, request_id, before_state, after_state, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+	`
+	// Пустые параметры фильтра не ограничивают выборку
+	queryListAuditRecords = `
+		SELECT id, action, entity_type, entity_id, actor, request_id, before_state, after_state, created_at
+		FROM audit_log
+		WHERE (?1 = '' OR actor = ?1)
+			AND (?2 = '' OR request_id = ?2)
+			AND (?3 = '' OR action = ?3)
+			AND (?4 = '' OR entity_type = ?4)
+			AND (?5 = '' OR entity_id = ?5)
+			AND (?6 IS NULL OR created_at >= ?6)
+			AND (?7 IS NULL OR created_at < ?7)
+			AND (?8 = 0 OR id < ?8)
+		ORDER BY id DESC
+		LIMIT ?9
+	`
+	queryDeleteAuditRecordsBefore = `DELETE FROM audit_log WHERE created_at < ?`
+)
+
+type AuditRepository struct {
+	db *sql.DB
+}
+
+func NewAuditRepository(db *sql.DB) *AuditRepository {
+	return &AuditRepository{db: db}
+}
+
+// AddRecords дописывает записи в журнал аудита и присваивает им ID
+func (r *AuditRepository) AddRecords(ctx context.Context, records []entity.AuditRecord) error {
+	if len(records) == 0 {
+		return nil
+	}
+
+	// Запрос подготавливается один раз на всю пачку записей
+	stmt, err := conn(ctx, r.db).PrepareContext(ctx, queryAddAuditRecord)
+	if err != nil {
+		return fmt.Errorf("prepare audit record: %w", err)
+	}
+	defer stmt.Close()
+
+	createdAt := now()
+	for i := range records {
+		result, err := stmt.ExecContext(ctx,
+			records[i].Action,
+			records[i].EntityType,
+			records[i].EntityID,
+			records[i].Actor,
+			records[i].RequestID,
+			string(records[i].Before),
+			string(records[i].After),
+			createdAt,
+		)
+		if err != nil {
+			return fmt.Errorf("add audit record: %w", err)
+		}
+
+		id, err := result.LastInsertId()
+		if err != nil {
+			return fmt.Errorf("get audit record id: %w", err)
+		}
+		records[i].ID = id
+		records[i].CreatedAt = createdAt
+	}
+
+	return nil
+}
+
+// ListRecords получает записи журнала аудита по фильтру от новых к старым
+func (r *AuditRepository) ListRecords(ctx context.Context, filter entity.AuditFilter) ([]entity.AuditRecord, error) {
+	rows, err := conn(ctx, r.db).QueryContext(ctx, queryListAuditRecords,
+		filter.Actor,
+		filter.RequestID,
+		filter.Action,
+		filter.EntityType,
+		filter.EntityID,
+		utcOrNil(filter.From),
+		utcOrNil(filter.To),
+		filter.BeforeID,
+		filter.Limit,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("list audit records: %w", err)
+	}
+	defer rows.Close()
+
+	records := []entity.AuditRecord{}
+	for rows.Next() {
+		var (
+			record        entity.AuditRecord
+			before, after string
+		)
+		err := rows.Scan(
+			&record.ID,
+			&record.Action,
+			&record.EntityType,
+			&record.EntityID,
+			&record.Actor,
+			&record.RequestID,
+			&before,
+			&after,
+			&record.CreatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("scan audit record: %w", err)
+		}
+		record.Before = []byte(before)
+		record.After = []byte(after)
+		records = append(records, record)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate audit records: %w", err)
+	}
+
+	return records, nil
+}
+
+// DeleteBefore удаляет записи журнала аудита, созданные раньше указанного времени
+func (r *AuditRepository) DeleteBefore(ctx context.Context, before time.Time) (int64, error) {
+	result, err := conn(ctx, r.db).ExecContext(ctx, queryDeleteAuditRecordsBefore, before.UTC())
+	if err != nil {
+		return 0, fmt.Errorf("delete audit records: %w", err)
+	}
+
+	deleted, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("get deleted audit records: %w", err)
+	}
+
+	return deleted, nil
+}
+
+// utcOrNil переводит необязательное время в UTC, чтобы оно сравнивалось со временем в базе как строка
+func utcOrNil(t *time.Time) any {
+	if t == nil {
+		return nil
+	}
+	return t.UTC()
+}
@@ -0,0 +1,46 @@
+-- Create teams table
+CREATE TABLE IF NOT EXISTS teams (
+    team_name TEXT PRIMARY KEY,
+    default_max_open_reviews INTEGER CHECK (default_max_open_reviews >= 0)
+);
+
+-- Create users table
+CREATE TABLE IF NOT EXISTS users (
+    user_id TEXT PRIMARY KEY,
+    username TEXT NOT NULL,
+    team_name TEXT NOT NULL,
+    is_active BOOLEAN NOT NULL DEFAULT 1,
+    max_open_reviews INTEGER CHECK (max_open_reviews >= 0),
+    FOREIGN KEY (team_name) REFERENCES teams(team_name) ON DELETE CASCADE
+);
+
+-- Create pull_requests table
+CREATE TABLE IF NOT EXISTS pull_requests (
+    pull_request_id TEXT PRIMARY KEY,
+    pull_request_name TEXT NOT NULL,
+    author_id TEXT NOT NULL,
+    status TEXT NOT NULL CHECK (status IN ('OPEN', 'MERGED')),
+    created_at DATETIME NOT NULL,
+    merged_at DATETIME,
+    need_more_reviewers BOOLEAN NOT NULL DEFAULT 0,
+    version INTEGER NOT NULL DEFAULT 1,
+    FOREIGN KEY (author_id) REFERENCES users(user_id) ON DELETE CASCADE
+);
+
+-- Create pull_request_reviewers table (many-to-many relationship)
+CREATE TABLE IF NOT EXISTS pull_request_reviewers (
+    pull_request_id TEXT NOT NULL,
+    user_id TEXT NOT NULL,
+    assigned_at DATETIME NOT NULL,
+    PRIMARY KEY (pull_request_id, user_id),
+    FOREIGN KEY (pull_request_id) REFERENCES pull_requests(pull_request_id) ON DELETE CASCADE,
+    FOREIGN KEY (user_id) REFERENCES users(user_id) ON DELETE CASCADE
+);
+
+-- Create indexes for better performance
+CREATE INDEX IF NOT EXISTS idx_users_team_name ON users(team_name);
+CREATE INDEX IF NOT EXISTS idx_pull_requests_author_id ON pull_requests(author_id);
+CREATE INDEX IF NOT EXISTS idx_pull_requests_status ON pull_requests(status);
+CREATE INDEX IF NOT EXISTS idx_pull_request_reviewers_user_id ON pull_request_reviewers(user_id);
+CREATE INDEX IF NOT EXISTS idx_pull_requests_created_at ON pull_requests(created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_pull_requests_need_more_reviewers ON pull_requests(created_at) WHERE status = 'OPEN' AND need_more_reviewers;
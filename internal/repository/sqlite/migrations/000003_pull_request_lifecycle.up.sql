@@ -0,0 +1,26 @@
+-- Draft and closed pull requests.
+-- SQLite cannot alter a CHECK constraint, so the table is rebuilt;
+-- foreign keys are disabled by the migration runner while the old table is dropped
+CREATE TABLE pull_requests_new (
+    pull_request_id TEXT PRIMARY KEY,
+    pull_request_name TEXT NOT NULL,
+    author_id TEXT NOT NULL,
+    status TEXT NOT NULL CHECK (status IN ('DRAFT', 'OPEN', 'MERGED', 'CLOSED')),
+    created_at DATETIME NOT NULL,
+    merged_at DATETIME,
+    need_more_reviewers BOOLEAN NOT NULL DEFAULT 0,
+    version INTEGER NOT NULL DEFAULT 1,
+    FOREIGN KEY (author_id) REFERENCES users(user_id) ON DELETE CASCADE
+);
+
+INSERT INTO pull_requests_new (pull_request_id, pull_request_name, author_id, status, created_at, merged_at, need_more_reviewers, version)
+SELECT pull_request_id, pull_request_name, author_id, status, created_at, merged_at, need_more_reviewers, version
+FROM pull_requests;
+
+DROP TABLE pull_requests;
+ALTER TABLE pull_requests_new RENAME TO pull_requests;
+
+CREATE INDEX IF NOT EXISTS idx_pull_requests_author_id ON pull_requests(author_id);
+CREATE INDEX IF NOT EXISTS idx_pull_requests_status ON pull_requests(status);
+CREATE INDEX IF NOT EXISTS idx_pull_requests_created_at ON pull_requests(created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_pull_requests_need_more_reviewers ON pull_requests(created_at) WHERE status = 'OPEN' AND need_more_reviewers;
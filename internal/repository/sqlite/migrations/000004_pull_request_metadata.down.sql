@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS idx_pull_request_changes_pull_request_id;
+
+DROP TABLE IF EXISTS pull_request_changes;
+
+ALTER TABLE pull_requests DROP COLUMN metadata;
+ALTER TABLE pull_requests DROP COLUMN labels;
+ALTER TABLE pull_requests DROP COLUMN description;
@@ -0,0 +1,17 @@
+-- Editable pull request metadata; labels and metadata are stored as JSON text
+ALTER TABLE pull_requests ADD COLUMN description TEXT NOT NULL DEFAULT '';
+ALTER TABLE pull_requests ADD COLUMN labels TEXT NOT NULL DEFAULT '[]';
+ALTER TABLE pull_requests ADD COLUMN metadata TEXT NOT NULL DEFAULT '{}';
+
+-- History of pull request edits with previous and new values (JSON text)
+CREATE TABLE IF NOT EXISTS pull_request_changes (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    pull_request_id TEXT NOT NULL,
+    field TEXT NOT NULL,
+    old_value TEXT NOT NULL,
+    new_value TEXT NOT NULL,
+    changed_at DATETIME NOT NULL,
+    FOREIGN KEY (pull_request_id) REFERENCES pull_requests(pull_request_id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_pull_request_changes_pull_request_id ON pull_request_changes(pull_request_id, id);
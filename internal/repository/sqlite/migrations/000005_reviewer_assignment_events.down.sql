@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_reviewer_assignment_events_pull_request_id;
+
+DROP TABLE IF EXISTS reviewer_assignment_events;
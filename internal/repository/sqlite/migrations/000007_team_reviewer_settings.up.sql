@@ -0,0 +1,4 @@
+-- Number of reviewers on pull requests of the team's authors:
+-- max_reviewers are assigned automatically, min_reviewers are required for merge
+ALTER TABLE teams ADD COLUMN max_reviewers INTEGER NOT NULL DEFAULT 2 CHECK (max_reviewers BETWEEN 1 AND 10);
+ALTER TABLE teams ADD COLUMN min_reviewers INTEGER NOT NULL DEFAULT 0 CHECK (min_reviewers >= 0 AND min_reviewers <= max_reviewers);
@@ -0,0 +1,11 @@
+-- Ordered fallback teams that supply reviewers when the author's team has too few candidates
+CREATE TABLE IF NOT EXISTS team_fallbacks (
+    team_name TEXT NOT NULL,
+    position INTEGER NOT NULL CHECK (position >= 0),
+    fallback_team_name TEXT NOT NULL,
+    PRIMARY KEY (team_name, position),
+    UNIQUE (team_name, fallback_team_name),
+    CHECK (fallback_team_name <> team_name),
+    FOREIGN KEY (team_name) REFERENCES teams(team_name) ON DELETE CASCADE,
+    FOREIGN KEY (fallback_team_name) REFERENCES teams(team_name) ON DELETE CASCADE
+);
@@ -0,0 +1,12 @@
+-- Paths changed by a pull request (JSON text), used to match code ownership rules
+ALTER TABLE pull_requests ADD COLUMN changed_files TEXT NOT NULL DEFAULT '[]';
+
+-- Ordered CODEOWNERS-style rules of a team; owners are stored as JSON text, the last rule matching a file wins
+CREATE TABLE IF NOT EXISTS code_owner_rules (
+    team_name TEXT NOT NULL,
+    position INTEGER NOT NULL CHECK (position >= 0),
+    pattern TEXT NOT NULL,
+    owners TEXT NOT NULL,
+    PRIMARY KEY (team_name, position),
+    FOREIGN KEY (team_name) REFERENCES teams(team_name) ON DELETE CASCADE
+);
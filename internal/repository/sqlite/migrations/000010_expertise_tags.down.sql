@@ -0,0 +1,2 @@
+ALTER TABLE pull_requests DROP COLUMN required_tags;
+ALTER TABLE users DROP COLUMN expertise_tags;
@@ -0,0 +1,3 @@
+-- Reviewer expertise tags and the tags a pull request requires from its reviewers (JSON text)
+ALTER TABLE users ADD COLUMN expertise_tags TEXT NOT NULL DEFAULT '[]';
+ALTER TABLE pull_requests ADD COLUMN required_tags TEXT NOT NULL DEFAULT '[]';
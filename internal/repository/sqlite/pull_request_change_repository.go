@@ -0,0 +1,78 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"internship/internal/domain/entity"
+)
+
+const (
+	queryAddChange = `
+		INSERT INTO pull_request_changes (pull_request_id, field, old_value, new_value, changed_at)
+		VALUES (?, ?, ?, ?, ?)
+	`
+	queryGetChanges = `
+		SELECT field, old_value, new_value, changed_at
+		FROM pull_request_changes
+		WHERE pull_request_id = ?
+		ORDER BY id
+	`
+)
+
+type PullRequestChangeRepository struct {
+	db *sql.DB
+}
+
+func NewPullRequestChangeRepository(db *sql.DB) *PullRequestChangeRepository {
+	return &PullRequestChangeRepository{db: db}
+}
+
+// AddChanges сохраняет правки полей PR
+func (r *PullRequestChangeRepository) AddChanges(ctx context.Context, prID string, changes []entity.PullRequestChange) error {
+	changedAt := now()
+	for i := range changes {
+		_, err := conn(ctx, r.db).ExecContext(ctx, queryAddChange,
+			prID,
+			changes[i].Field,
+			string(changes[i].OldValue),
+			string(changes[i].NewValue),
+			changedAt,
+		)
+		if err != nil {
+			return fmt.Errorf("add pull request change: %w", err)
+		}
+		changes[i].ChangedAt = changedAt
+	}
+
+	return nil
+}
+
+// GetChanges получает историю правок PR в порядке внесения
+func (r *PullRequestChangeRepository) GetChanges(ctx context.Context, prID string) ([]entity.PullRequestChange, error) {
+	rows, err := conn(ctx, r.db).QueryContext(ctx, queryGetChanges, prID)
+	if err != nil {
+		return nil, fmt.Errorf("get pull request changes: %w", err)
+	}
+	defer rows.Close()
+
+	changes := []entity.PullRequestChange{}
+	for rows.Next() {
+		var (
+			change             entity.PullRequestChange
+			oldValue, newValue string
+		)
+		if err := rows.Scan(&change.Field, &oldValue, &newValue, &change.ChangedAt); err != nil {
+			return nil, fmt.Errorf("scan pull request change: %w", err)
+		}
+		change.OldValue = []byte(oldValue)
+		change.NewValue = []byte(newValue)
+		changes = append(changes, change)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate pull request changes: %w", err)
+	}
+
+	return changes, nil
+}
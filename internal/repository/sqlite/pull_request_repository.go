@@ -0,0 +1,365 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"internship/internal/domain/entity"
+	"time"
+)
+
+const (
+	queryCreatePR = `
+		INSERT INTO pull_requests (pull_request_id, pull_request_name, description, labels, metadata, changed_files, required_tags, author_id, status, created_at, merged_at, need_more_reviewers)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+		RETURNING version
+	`
+
+	// prColumns — колонки PR вместе с ревьюверами в порядке назначения и решениями ревьюверов
+	// (JSON-массивами). Связанные данные читаются в том же запросе, чтобы списки PR
+	// не требовали запроса на каждую строку
+	prColumns = `
+		pr.pull_request_id, pr.pull_request_name, pr.description, pr.labels, pr.metadata, pr.changed_files, pr.required_tags, pr.author_id, pr.status, pr.created_at, pr.merged_at, pr.need_more_reviewers, pr.version,
+		(
+			SELECT json_group_array(r.user_id ORDER BY r.assigned_at, r.rowid)
+			FROM pull_request_reviewers r
+			WHERE r.pull_request_id = pr.pull_request_id
+		),
+		(
+			SELECT json_group_array(json_object(
+				'reviewer_id', d.reviewer_id,
+				'decision', d.decision,
+				'comment', d.comment,
+				'created_at', d.created_at
+			) ORDER BY d.id)
+			FROM review_decisions d
+			WHERE d.pull_request_id = pr.pull_request_id
+		)
+	`
+
+	queryGetPRByID = `
+		SELECT ` + prColumns + `
+		FROM pull_requests pr
+		WHERE pr.pull_request_id = ?
+	`
+
+	queryUpdatePR = `
+		UPDATE pull_requests
+		SET pull_request_name = ?, description = ?, labels = ?, metadata = ?, status = ?, merged_at = ?, need_more_reviewers = ?, version = version + 1
+		WHERE pull_request_id = ? AND version = ?
+		RETURNING version
+	`
+
+	querySetNeedMoreReviewers = `
+		UPDATE pull_requests
+		SET need_more_reviewers = ?, version = version + 1
+		WHERE pull_request_id = ? AND version = ?
+	`
+
+	queryExistsPR = `
+		SELECT EXISTS(SELECT 1 FROM pull_requests WHERE pull_request_id = ?)
+	`
+
+	queryGetByReviewer = `
+		SELECT ` + prColumns + `
+		FROM pull_requests pr
+		WHERE EXISTS (
+			SELECT 1 FROM pull_request_reviewers prr
+			WHERE prr.pull_request_id = pr.pull_request_id AND prr.user_id = ?
+		)
+		ORDER BY pr.created_at DESC
+	`
+
+	queryGetOpenPRsByReviewers = `
+		SELECT ` + prColumns + `
+		FROM pull_requests pr
+		WHERE EXISTS (
+			SELECT 1 FROM pull_request_reviewers prr
+			WHERE prr.pull_request_id = pr.pull_request_id AND prr.user_id IN (SELECT value FROM json_each(?))
+		) AND pr.status = ?
+		ORDER BY pr.created_at DESC
+	`
+
+	queryGetOpenNeedingReviewers = `
+		SELECT ` + prColumns + `
+		FROM pull_requests pr
+		WHERE pr.status = ? AND pr.need_more_reviewers
+		ORDER BY pr.created_at
+	`
+)
+
+type PullRequestRepository struct {
+	db *sql.DB
+}
+
+func NewPullRequestRepository(db *sql.DB) *PullRequestRepository {
+	return &PullRequestRepository{db: db}
+}
+
+// Create создает новый PR
+func (r *PullRequestRepository) Create(ctx context.Context, pr *entity.PullRequest) error {
+	labels, metadata, err := encodeMetadata(pr)
+	if err != nil {
+		return fmt.Errorf("create pull request: %w", err)
+	}
+	changedFiles, err := encodeStrings(pr.ChangedFiles)
+	if err != nil {
+		return fmt.Errorf("create pull request: encode changed files: %w", err)
+	}
+	requiredTags, err := encodeStrings(pr.RequiredTags)
+	if err != nil {
+		return fmt.Errorf("create pull request: encode required tags: %w", err)
+	}
+
+	err = conn(ctx, r.db).QueryRowContext(ctx, queryCreatePR,
+		pr.PullRequestID,
+		pr.PullRequestName,
+		pr.Description,
+		labels,
+		metadata,
+		changedFiles,
+		requiredTags,
+		pr.AuthorID,
+		pr.Status,
+		now(),
+		utc(pr.MergedAt),
+		pr.NeedMoreReviewers,
+	).Scan(&pr.Version)
+
+	if err != nil {
+		if isUniqueViolation(err) {
+			return entity.ErrPRExists
+		}
+		return fmt.Errorf("create pull request: %w", err)
+	}
+
+	return nil
+}
+
+// GetByID получает PR по ID с ревьюверами
+func (r *PullRequestRepository) GetByID(ctx context.Context, prID string) (*entity.PullRequest, error) {
+	var pr entity.PullRequest
+	err := scanPullRequest(conn(ctx, r.db).QueryRowContext(ctx, queryGetPRByID, prID), &pr)
+
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, entity.ErrPRNotFound
+		}
+		return nil, fmt.Errorf("get pull request by id: %w", err)
+	}
+
+	return &pr, nil
+}
+
+// GetByIDForUpdate получает PR по ID с ревьюверами. Транзакции SQLite выполняются
+// через одно соединение по очереди, поэтому отдельная блокировка строки не нужна
+func (r *PullRequestRepository) GetByIDForUpdate(ctx context.Context, prID string) (*entity.PullRequest, error) {
+	return r.GetByID(ctx, prID)
+}
+
+// Update обновляет PR, если его версия не изменилась с момента чтения, и увеличивает версию
+func (r *PullRequestRepository) Update(ctx context.Context, pr *entity.PullRequest) error {
+	labels, metadata, err := encodeMetadata(pr)
+	if err != nil {
+		return fmt.Errorf("update pull request: %w", err)
+	}
+
+	err = conn(ctx, r.db).QueryRowContext(ctx, queryUpdatePR,
+		pr.PullRequestName,
+		pr.Description,
+		labels,
+		metadata,
+		pr.Status,
+		utc(pr.MergedAt),
+		pr.NeedMoreReviewers,
+		pr.PullRequestID,
+		pr.Version,
+	).Scan(&pr.Version)
+
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return r.versionConflictOrNotFound(ctx, pr.PullRequestID)
+		}
+		return fmt.Errorf("update pull request: %w", err)
+	}
+
+	return nil
+}
+
+// versionConflictOrNotFound различает устаревшую версию и отсутствие PR после неудачного обновления
+func (r *PullRequestRepository) versionConflictOrNotFound(ctx context.Context, prID string) error {
+	exists, err := r.Exists(ctx, prID)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return entity.ErrPRNotFound
+	}
+	return entity.ErrVersionConflict
+}
+
+// Exists проверяет существование PR
+func (r *PullRequestRepository) Exists(ctx context.Context, prID string) (bool, error) {
+	var exists bool
+	err := conn(ctx, r.db).QueryRowContext(ctx, queryExistsPR, prID).Scan(&exists)
+	if err != nil {
+		return false, fmt.Errorf("check pr exists: %w", err)
+	}
+
+	return exists, nil
+}
+
+// GetByReviewer получает PR'ы где пользователь назначен ревьювером
+func (r *PullRequestRepository) GetByReviewer(ctx context.Context, userID string) ([]entity.PullRequest, error) {
+	prs, err := r.queryPullRequests(ctx, queryGetByReviewer, userID)
+	if err != nil {
+		return nil, fmt.Errorf("get prs by reviewer: %w", err)
+	}
+
+	return prs, nil
+}
+
+// GetOpenPRsByReviewers получает открытые PR'ы для списка ревьюверов
+func (r *PullRequestRepository) GetOpenPRsByReviewers(ctx context.Context, reviewerIDs []string) ([]entity.PullRequest, error) {
+	if len(reviewerIDs) == 0 {
+		return []entity.PullRequest{}, nil
+	}
+
+	ids, err := json.Marshal(reviewerIDs)
+	if err != nil {
+		return nil, fmt.Errorf("encode reviewer ids: %w", err)
+	}
+
+	prs, err := r.queryPullRequests(ctx, queryGetOpenPRsByReviewers, string(ids), entity.PRStatusOpen)
+	if err != nil {
+		return nil, fmt.Errorf("get open prs by reviewers: %w", err)
+	}
+
+	return prs, nil
+}
+
+// GetOpenNeedingReviewers получает открытые PR'ы, которым не хватает ревьюверов
+func (r *PullRequestRepository) GetOpenNeedingReviewers(ctx context.Context) ([]entity.PullRequest, error) {
+	prs, err := r.queryPullRequests(ctx, queryGetOpenNeedingReviewers, entity.PRStatusOpen)
+	if err != nil {
+		return nil, fmt.Errorf("get open prs needing reviewers: %w", err)
+	}
+
+	return prs, nil
+}
+
+// queryPullRequests выполняет запрос списка PR, выбирающий колонки prColumns
+func (r *PullRequestRepository) queryPullRequests(ctx context.Context, query string, args ...any) ([]entity.PullRequest, error) {
+	rows, err := conn(ctx, r.db).QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var prs []entity.PullRequest
+	for rows.Next() {
+		var pr entity.PullRequest
+		if err := scanPullRequest(rows, &pr); err != nil {
+			return nil, fmt.Errorf("scan pull request: %w", err)
+		}
+		prs = append(prs, pr)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate pull requests: %w", err)
+	}
+
+	return prs, nil
+}
+
+// scanPullRequest читает строку PR, выбранную колонками prColumns
+func scanPullRequest(row interface{ Scan(dest ...any) error }, pr *entity.PullRequest) error {
+	var labels, metadata, changedFiles, requiredTags, reviewers, reviews string
+	err := row.Scan(
+		&pr.PullRequestID,
+		&pr.PullRequestName,
+		&pr.Description,
+		&labels,
+		&metadata,
+		&changedFiles,
+		&requiredTags,
+		&pr.AuthorID,
+		&pr.Status,
+		&pr.CreatedAt,
+		&pr.MergedAt,
+		&pr.NeedMoreReviewers,
+		&pr.Version,
+		&reviewers,
+		&reviews,
+	)
+	if err != nil {
+		return err
+	}
+
+	if err := json.Unmarshal([]byte(labels), &pr.Labels); err != nil {
+		return fmt.Errorf("decode labels: %w", err)
+	}
+	if err := json.Unmarshal([]byte(metadata), &pr.Metadata); err != nil {
+		return fmt.Errorf("decode metadata: %w", err)
+	}
+	if err := json.Unmarshal([]byte(changedFiles), &pr.ChangedFiles); err != nil {
+		return fmt.Errorf("decode changed files: %w", err)
+	}
+	if err := json.Unmarshal([]byte(requiredTags), &pr.RequiredTags); err != nil {
+		return fmt.Errorf("decode required tags: %w", err)
+	}
+	if err := json.Unmarshal([]byte(reviewers), &pr.AssignedReviewers); err != nil {
+		return fmt.Errorf("decode reviewers: %w", err)
+	}
+	if err := json.Unmarshal([]byte(reviews), &pr.Reviews); err != nil {
+		return fmt.Errorf("decode reviews: %w", err)
+	}
+
+	return nil
+}
+
+// encodeMetadata кодирует метки и метаданные PR в JSON; отсутствующие значения хранятся пустыми
+func encodeMetadata(pr *entity.PullRequest) (string, string, error) {
+	labels := pr.Labels
+	if labels == nil {
+		labels = []string{}
+	}
+	encodedLabels, err := json.Marshal(labels)
+	if err != nil {
+		return "", "", fmt.Errorf("encode labels: %w", err)
+	}
+
+	metadata := pr.Metadata
+	if metadata == nil {
+		metadata = map[string]string{}
+	}
+	encodedMetadata, err := json.Marshal(metadata)
+	if err != nil {
+		return "", "", fmt.Errorf("encode metadata: %w", err)
+	}
+
+	return string(encodedLabels), string(encodedMetadata), nil
+}
+
+// encodeStrings кодирует список строк (измененные файлы, теги) в JSON; отсутствующий список хранится пустым
+func encodeStrings(values []string) (string, error) {
+	if values == nil {
+		values = []string{}
+	}
+	encoded, err := json.Marshal(values)
+	if err != nil {
+		return "", err
+	}
+	return string(encoded), nil
+}
+
+// utc переводит необязательное время в UTC
+func utc(t *time.Time) *time.Time {
+	if t == nil {
+		return nil
+	}
+	u := t.UTC()
+	return &u
+}
@@ -0,0 +1,43 @@
+package sqlite_test
+
+import (
+	"context"
+	"fmt"
+	"internship/internal/repository/repotest"
+	"internship/internal/repository/sqlite"
+	"path/filepath"
+	"testing"
+)
+
+// newFactory возвращает фабрику репозиториев, каждый раз над новым файлом базы в dir
+func newFactory(dir string) repotest.Factory {
+	n := 0
+	return func(ctx context.Context) (*repotest.Repositories, func(), error) {
+		n++
+		storage, err := sqlite.NewDatabase(ctx, filepath.Join(dir, fmt.Sprintf("check%d.db", n)))
+		if err != nil {
+			return nil, nil, err
+		}
+		db := storage.GetDB()
+		return &repotest.Repositories{
+			Team:            sqlite.NewTeamRepository(db),
+			User:            sqlite.NewUserRepository(db),
+			PullRequest:     sqlite.NewPullRequestRepository(db),
+			Reviewer:        sqlite.NewReviewerRepository(db),
+			Review:          sqlite.NewReviewDecisionRepository(db),
+			Change:          sqlite.NewPullRequestChangeRepository(db),
+			AssignmentEvent: sqlite.NewAssignmentEventRepository(db),
+			Audit:           sqlite.NewAuditRepository(db),
+			Statistics:      sqlite.NewStatisticsRepository(db),
+			TxManager:       sqlite.NewTxManager(db),
+		}, func() { _ = storage.Close() }, nil
+	}
+}
+
+func TestRepositories(t *testing.T) {
+	repotest.Run(t, newFactory(t.TempDir()))
+}
+
+func BenchmarkGetByReviewer(b *testing.B) {
+	repotest.BenchmarkGetByReviewer(b, newFactory(b.TempDir()))
+}
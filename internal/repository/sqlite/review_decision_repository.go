@@ -0,0 +1,83 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"internship/internal/domain/entity"
+	"time"
+)
+
+const (
+	queryAddDecision = `
+		INSERT INTO review_decisions (pull_request_id, reviewer_id, decision, comment, created_at)
+		VALUES (?, ?, ?, ?, ?)
+	`
+	queryGetDecisions = `
+		SELECT reviewer_id, decision, comment, created_at
+		FROM review_decisions
+		WHERE pull_request_id = ?
+		ORDER BY id
+	`
+)
+
+type ReviewDecisionRepository struct {
+	db *sql.DB
+}
+
+func NewReviewDecisionRepository(db *sql.DB) *ReviewDecisionRepository {
+	return &ReviewDecisionRepository{db: db}
+}
+
+// AddDecision сохраняет решение ревьювера по PR
+func (r *ReviewDecisionRepository) AddDecision(ctx context.Context, prID string, decision *entity.ReviewDecision) error {
+	createdAt := now()
+	_, err := conn(ctx, r.db).ExecContext(ctx, queryAddDecision,
+		prID,
+		decision.ReviewerID,
+		decision.Decision,
+		decision.Comment,
+		createdAt.Format(time.RFC3339Nano),
+	)
+	if err != nil {
+		return fmt.Errorf("add review decision: %w", err)
+	}
+	decision.CreatedAt = createdAt
+
+	return nil
+}
+
+// GetDecisions получает решения ревьюверов по PR в порядке поступления
+func (r *ReviewDecisionRepository) GetDecisions(ctx context.Context, prID string) ([]entity.ReviewDecision, error) {
+	rows, err := conn(ctx, r.db).QueryContext(ctx, queryGetDecisions, prID)
+	if err != nil {
+		return nil, fmt.Errorf("get review decisions: %w", err)
+	}
+	defer rows.Close()
+
+	decisions := []entity.ReviewDecision{}
+	for rows.Next() {
+		var decision entity.ReviewDecision
+		var createdAt string
+		err := rows.Scan(
+			&decision.ReviewerID,
+			&decision.Decision,
+			&decision.Comment,
+			&createdAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("scan review decision: %w", err)
+		}
+		decision.CreatedAt, err = time.Parse(time.RFC3339Nano, createdAt)
+		if err != nil {
+			return nil, fmt.Errorf("parse review decision time: %w", err)
+		}
+		decisions = append(decisions, decision)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate review decisions: %w", err)
+	}
+
+	return decisions, nil
+}
@@ -0,0 +1,163 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"internship/internal/domain/entity"
+	"time"
+)
+
+const (
+	queryAssignReviewer = `
+		INSERT INTO pull_request_reviewers (pull_request_id, user_id, assigned_at)
+		VALUES (?, ?, ?)
+		ON CONFLICT (pull_request_id, user_id) DO NOTHING
+	`
+	queryRemoveReviewer = `
+		DELETE FROM pull_request_reviewers
+		WHERE pull_request_id = ? AND user_id = ?
+	`
+	// rowid упорядочивает назначения, сделанные в один момент времени, в порядке вставки
+	queryGetReviewers = `
+		SELECT user_id
+		FROM pull_request_reviewers
+		WHERE pull_request_id = ?
+		ORDER BY assigned_at, rowid
+	`
+	queryIsAssigned = `
+		SELECT EXISTS(SELECT 1 FROM pull_request_reviewers WHERE pull_request_id = ? AND user_id = ?)
+	`
+	queryInsertReviewer = queryAssignReviewer
+	queryDeleteReviewer = queryRemoveReviewer
+	// Список пользователей передается JSON-массивом: в SQLite нет параметров-массивов
+	queryCountOpenReviews = `
+		SELECT prr.user_id, COUNT(*)
+		FROM pull_request_reviewers prr
+		INNER JOIN pull_requests pr ON pr.pull_request_id = prr.pull_request_id
+		WHERE prr.user_id IN (SELECT value FROM json_each(?)) AND pr.status = ?
+		GROUP BY prr.user_id
+	`
+)
+
+type ReviewerRepository struct {
+	db *sql.DB
+}
+
+func NewReviewerRepository(db *sql.DB) *ReviewerRepository {
+	return &ReviewerRepository{db: db}
+}
+
+// AssignReviewer назначает ревьювера на PR
+func (r *ReviewerRepository) AssignReviewer(ctx context.Context, prID, userID string) error {
+	_, err := conn(ctx, r.db).ExecContext(ctx, queryAssignReviewer, prID, userID, now())
+	if err != nil {
+		return fmt.Errorf("assign reviewer: %w", err)
+	}
+
+	return nil
+}
+
+// RemoveReviewer удаляет ревьювера с PR
+func (r *ReviewerRepository) RemoveReviewer(ctx context.Context, prID, userID string) error {
+	_, err := conn(ctx, r.db).ExecContext(ctx, queryRemoveReviewer, prID, userID)
+	if err != nil {
+		return fmt.Errorf("remove reviewer: %w", err)
+	}
+
+	return nil
+}
+
+// GetReviewers получает список ревьюверов для PR
+func (r *ReviewerRepository) GetReviewers(ctx context.Context, prID string) ([]string, error) {
+	rows, err := conn(ctx, r.db).QueryContext(ctx, queryGetReviewers, prID)
+	if err != nil {
+		return nil, fmt.Errorf("get reviewers: %w", err)
+	}
+	defer rows.Close()
+
+	var reviewers []string
+	for rows.Next() {
+		var userID string
+		if err := rows.Scan(&userID); err != nil {
+			return nil, fmt.Errorf("scan reviewer: %w", err)
+		}
+		reviewers = append(reviewers, userID)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate reviewers: %w", err)
+	}
+
+	return reviewers, nil
+}
+
+// IsAssigned проверяет, назначен ли пользователь ревьювером на PR
+func (r *ReviewerRepository) IsAssigned(ctx context.Context, prID, userID string) (bool, error) {
+	var assigned bool
+	err := conn(ctx, r.db).QueryRowContext(ctx, queryIsAssigned, prID, userID).Scan(&assigned)
+	if err != nil {
+		return false, fmt.Errorf("check is assigned: %w", err)
+	}
+
+	return assigned, nil
+}
+
+// ReplaceReviewer заменяет одного ревьювера на другого в транзакции
+func (r *ReviewerRepository) ReplaceReviewer(ctx context.Context, prID, oldUserID, newUserID string) error {
+	return runInTx(ctx, r.db, func(ctx context.Context) error {
+		q := conn(ctx, r.db)
+
+		// Удаляем старого ревьювера
+		if _, err := q.ExecContext(ctx, queryDeleteReviewer, prID, oldUserID); err != nil {
+			return fmt.Errorf("remove old reviewer: %w", err)
+		}
+
+		// Добавляем нового ревьювера
+		if _, err := q.ExecContext(ctx, queryInsertReviewer, prID, newUserID, now()); err != nil {
+			return fmt.Errorf("assign new reviewer: %w", err)
+		}
+
+		return nil
+	})
+}
+
+// CountOpenReviews возвращает число открытых PR на ревью у каждого из пользователей одним запросом
+func (r *ReviewerRepository) CountOpenReviews(ctx context.Context, userIDs []string) (map[string]int, error) {
+	counts := make(map[string]int, len(userIDs))
+	if len(userIDs) == 0 {
+		return counts, nil
+	}
+
+	ids, err := json.Marshal(userIDs)
+	if err != nil {
+		return nil, fmt.Errorf("encode user ids: %w", err)
+	}
+
+	rows, err := conn(ctx, r.db).QueryContext(ctx, queryCountOpenReviews, string(ids), entity.PRStatusOpen)
+	if err != nil {
+		return nil, fmt.Errorf("count open reviews: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var userID string
+		var count int
+		if err := rows.Scan(&userID, &count); err != nil {
+			return nil, fmt.Errorf("scan open reviews count: %w", err)
+		}
+		counts[userID] = count
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate open reviews counts: %w", err)
+	}
+
+	return counts, nil
+}
+
+// now возвращает текущее время в UTC, чтобы время в базе сравнивалось как строки
+func now() time.Time {
+	return time.Now().UTC()
+}
@@ -0,0 +1,187 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"embed"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"modernc.org/sqlite"
+	sqlite3 "modernc.org/sqlite/lib"
+)
+
+//go:embed migrations/*.sql
+var migrationsFS embed.FS
+
+const (
+	queryCreateSchemaMigrations = `
+		CREATE TABLE IF NOT EXISTS schema_migrations (
+			version INTEGER PRIMARY KEY
+		)
+	`
+	queryGetAppliedMigrations = `SELECT version FROM schema_migrations`
+	queryInsertMigration      = `INSERT INTO schema_migrations (version) VALUES (?)`
+
+	// Миграции, пересоздающие таблицы, выполняются с отключенными внешними ключами:
+	// иначе удаление старой таблицы каскадно удалит связанные строки
+	queryDisableForeignKeys = `PRAGMA foreign_keys = OFF`
+	queryEnableForeignKeys  = `PRAGMA foreign_keys = ON`
+	queryForeignKeyCheck    = `PRAGMA foreign_key_check`
+)
+
+type Storage struct {
+	db *sql.DB
+}
+
+// NewDatabase открывает файл базы SQLite и применяет недостающие миграции
+func NewDatabase(ctx context.Context, path string) (*Storage, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create database directory: %w", err)
+	}
+
+	dsn := fmt.Sprintf("file:%s?_pragma=foreign_keys(1)&_pragma=busy_timeout(5000)&_pragma=journal_mode(WAL)", path)
+	db, err := sql.Open("sqlite", dsn)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open database: %w", err)
+	}
+
+	// SQLite допускает одного писателя: одно соединение сериализует транзакции
+	// и делает их изолированными друг от друга
+	db.SetMaxOpenConns(1)
+
+	if err := db.PingContext(ctx); err != nil {
+		_ = db.Close()
+		return nil, fmt.Errorf("failed to ping database: %w", err)
+	}
+
+	storage := &Storage{db: db}
+	if err := storage.migrate(ctx); err != nil {
+		_ = db.Close()
+		return nil, fmt.Errorf("failed to apply migrations: %w", err)
+	}
+
+	return storage, nil
+}
+
+func (d *Storage) GetDB() *sql.DB {
+	return d.db
+}
+
+func (d *Storage) Close() error {
+	if d.db != nil {
+		return d.db.Close()
+	}
+	return nil
+}
+
+// migrate применяет встроенные миграции, которые еще не были применены
+func (d *Storage) migrate(ctx context.Context) error {
+	if _, err := d.db.ExecContext(ctx, queryCreateSchemaMigrations); err != nil {
+		return fmt.Errorf("create schema_migrations: %w", err)
+	}
+
+	applied, err := d.appliedMigrations(ctx)
+	if err != nil {
+		return err
+	}
+
+	files, err := fs.Glob(migrationsFS, "migrations/*.up.sql")
+	if err != nil {
+		return fmt.Errorf("list migrations: %w", err)
+	}
+	sort.Strings(files)
+
+	// PRAGMA foreign_keys не действует внутри транзакции, поэтому переключается до применения миграций.
+	// Соединение единственное, так что настройка относится ко всем миграциям
+	if _, err := d.db.ExecContext(ctx, queryDisableForeignKeys); err != nil {
+		return fmt.Errorf("disable foreign keys: %w", err)
+	}
+	defer func() { _, _ = d.db.ExecContext(ctx, queryEnableForeignKeys) }()
+
+	for _, file := range files {
+		name := strings.TrimPrefix(file, "migrations/")
+		version, err := strconv.Atoi(strings.SplitN(name, "_", 2)[0])
+		if err != nil {
+			return fmt.Errorf("parse migration version %s: %w", name, err)
+		}
+		if applied[version] {
+			continue
+		}
+
+		script, err := migrationsFS.ReadFile(file)
+		if err != nil {
+			return fmt.Errorf("read migration %s: %w", name, err)
+		}
+
+		err = runInTx(ctx, d.db, func(ctx context.Context) error {
+			q := conn(ctx, d.db)
+			if _, err := q.ExecContext(ctx, string(script)); err != nil {
+				return err
+			}
+			if err := checkForeignKeys(ctx, q); err != nil {
+				return err
+			}
+			_, err := q.ExecContext(ctx, queryInsertMigration, version)
+			return err
+		})
+		if err != nil {
+			return fmt.Errorf("apply migration %s: %w", name, err)
+		}
+	}
+
+	return nil
+}
+
+// checkForeignKeys проверяет, что миграция не нарушила ссылочную целостность
+func checkForeignKeys(ctx context.Context, q querier) error {
+	rows, err := q.QueryContext(ctx, queryForeignKeyCheck)
+	if err != nil {
+		return fmt.Errorf("check foreign keys: %w", err)
+	}
+	defer rows.Close()
+
+	if rows.Next() {
+		return errors.New("foreign key constraint violated")
+	}
+	return rows.Err()
+}
+
+func (d *Storage) appliedMigrations(ctx context.Context) (map[int]bool, error) {
+	rows, err := d.db.QueryContext(ctx, queryGetAppliedMigrations)
+	if err != nil {
+		return nil, fmt.Errorf("get applied migrations: %w", err)
+	}
+	defer rows.Close()
+
+	applied := make(map[int]bool)
+	for rows.Next() {
+		var version int
+		if err := rows.Scan(&version); err != nil {
+			return nil, fmt.Errorf("scan migration version: %w", err)
+		}
+		applied[version] = true
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate migrations: %w", err)
+	}
+
+	return applied, nil
+}
+
+// isUniqueViolation проверяет, что ошибка вызвана нарушением ограничения уникальности
+func isUniqueViolation(err error) bool {
+	var sqliteErr *sqlite.Error
+	if !errors.As(err, &sqliteErr) {
+		return false
+	}
+	code := sqliteErr.Code()
+	return code == sqlite3.SQLITE_CONSTRAINT_PRIMARYKEY || code == sqlite3.SQLITE_CONSTRAINT_UNIQUE
+}
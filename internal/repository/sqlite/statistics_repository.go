@@ -0,0 +1,78 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+)
+
+const (
+	queryGetAssignmentStats = `
+		SELECT u.username, COUNT(prr.pull_request_id) as assignment_count
+		FROM users u
+		LEFT JOIN pull_request_reviewers prr ON u.user_id = prr.user_id
+		GROUP BY u.user_id, u.username
+		ORDER BY assignment_count DESC
+	`
+	queryGetPRStats = `
+		SELECT
+			COUNT(*) as total_prs,
+			COUNT(CASE WHEN status = 'OPEN' THEN 1 END) as open_prs,
+			COUNT(CASE WHEN status = 'MERGED' THEN 1 END) as merged_prs,
+			COUNT(CASE WHEN status = 'DRAFT' THEN 1 END) as draft_prs,
+			COUNT(CASE WHEN status = 'CLOSED' THEN 1 END) as closed_prs
+		FROM pull_requests
+	`
+)
+
+type StatisticsRepository struct {
+	db *sql.DB
+}
+
+func NewStatisticsRepository(db *sql.DB) *StatisticsRepository {
+	return &StatisticsRepository{db: db}
+}
+
+// GetAssignmentStats возвращает статистику назначений по пользователям
+func (r *StatisticsRepository) GetAssignmentStats(ctx context.Context) (map[string]int, error) {
+	rows, err := conn(ctx, r.db).QueryContext(ctx, queryGetAssignmentStats)
+	if err != nil {
+		return nil, fmt.Errorf("get assignment stats: %w", err)
+	}
+	defer rows.Close()
+
+	stats := make(map[string]int)
+	for rows.Next() {
+		var username string
+		var count int
+		if err := rows.Scan(&username, &count); err != nil {
+			return nil, fmt.Errorf("scan assignment stat: %w", err)
+		}
+		stats[username] = count
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate assignment stats: %w", err)
+	}
+
+	return stats, nil
+}
+
+// GetPRStats возвращает общую статистику по PR
+func (r *StatisticsRepository) GetPRStats(ctx context.Context) (map[string]interface{}, error) {
+	var totalPRs, openPRs, mergedPRs, draftPRs, closedPRs int
+	err := conn(ctx, r.db).QueryRowContext(ctx, queryGetPRStats).Scan(&totalPRs, &openPRs, &mergedPRs, &draftPRs, &closedPRs)
+	if err != nil {
+		return nil, fmt.Errorf("get pr stats: %w", err)
+	}
+
+	stats := map[string]interface{}{
+		"total_prs":  totalPRs,
+		"open_prs":   openPRs,
+		"merged_prs": mergedPRs,
+		"draft_prs":  draftPRs,
+		"closed_prs": closedPRs,
+	}
+
+	return stats, nil
+}
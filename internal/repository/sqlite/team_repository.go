@@ -0,0 +1,184 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"internship/internal/domain/entity"
+)
+
+const (
+	queryCreateTeam = `
+		INSERT INTO teams (team_name, default_max_open_reviews, min_reviewers, max_reviewers)
+		VALUES (?, ?, ?, ?)`
+	queryGetTeamByName = `
+		SELECT team_name, default_max_open_reviews, min_reviewers, max_reviewers
+		FROM teams
+		WHERE team_name = ?`
+	queryCheckTeamExists = `SELECT EXISTS(SELECT 1 FROM teams WHERE team_name = ?)`
+
+	queryUpdateReviewerSettings = `UPDATE teams SET min_reviewers = ?2, max_reviewers = ?3 WHERE team_name = ?1`
+
+	queryDeleteFallbackTeams = `DELETE FROM team_fallbacks WHERE team_name = ?`
+	queryAddFallbackTeam     = `INSERT INTO team_fallbacks (team_name, position, fallback_team_name) VALUES (?, ?, ?)`
+	queryGetFallbackTeams    = `SELECT fallback_team_name FROM team_fallbacks WHERE team_name = ? ORDER BY position`
+
+	queryDeleteCodeOwnerRules = `DELETE FROM code_owner_rules WHERE team_name = ?`
+	queryAddCodeOwnerRule     = `INSERT INTO code_owner_rules (team_name, position, pattern, owners) VALUES (?, ?, ?, ?)`
+	queryGetCodeOwnerRules    = `SELECT pattern, owners FROM code_owner_rules WHERE team_name = ? ORDER BY position`
+)
+
+type TeamRepository struct {
+	db *sql.DB
+}
+
+func NewTeamRepository(db *sql.DB) *TeamRepository {
+	return &TeamRepository{db: db}
+}
+
+// Create создает новую команду
+func (r *TeamRepository) Create(ctx context.Context, team *entity.Team) error {
+	_, err := conn(ctx, r.db).ExecContext(ctx, queryCreateTeam, team.TeamName, team.DefaultMaxOpenReviews, team.MinReviewers, team.MaxReviewers)
+	if err != nil {
+		if isUniqueViolation(err) {
+			return fmt.Errorf("create team: %w", entity.ErrTeamExists)
+		}
+		return fmt.Errorf("create team: %w", err)
+	}
+
+	return nil
+}
+
+// GetByName получает команду по имени
+func (r *TeamRepository) GetByName(ctx context.Context, teamName string) (*entity.Team, error) {
+	var team entity.Team
+	err := conn(ctx, r.db).QueryRowContext(ctx, queryGetTeamByName, teamName).Scan(
+		&team.TeamName,
+		&team.DefaultMaxOpenReviews,
+		&team.MinReviewers,
+		&team.MaxReviewers,
+	)
+
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, entity.ErrTeamNotFound
+		}
+		return nil, fmt.Errorf("get team by name: %w", err)
+	}
+
+	return &team, nil
+}
+
+// Exists проверяет существование команды
+func (r *TeamRepository) Exists(ctx context.Context, teamName string) (bool, error) {
+	var exists bool
+	err := conn(ctx, r.db).QueryRowContext(ctx, queryCheckTeamExists, teamName).Scan(&exists)
+	if err != nil {
+		return false, fmt.Errorf("check team exists: %w", err)
+	}
+
+	return exists, nil
+}
+
+// UpdateReviewerSettings сохраняет настройки числа ревьюверов команды
+func (r *TeamRepository) UpdateReviewerSettings(ctx context.Context, teamName string, settings entity.ReviewerSettings) error {
+	result, err := conn(ctx, r.db).ExecContext(ctx, queryUpdateReviewerSettings, teamName, settings.MinReviewers, settings.MaxReviewers)
+	if err != nil {
+		return fmt.Errorf("update reviewer settings: %w", err)
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("update reviewer settings: %w", err)
+	}
+	if affected == 0 {
+		return entity.ErrTeamNotFound
+	}
+
+	return nil
+}
+
+// SetFallbackTeams заменяет резервные команды команды, сохраняя их порядок
+func (r *TeamRepository) SetFallbackTeams(ctx context.Context, teamName string, fallbackTeams []string) error {
+	if _, err := conn(ctx, r.db).ExecContext(ctx, queryDeleteFallbackTeams, teamName); err != nil {
+		return fmt.Errorf("delete fallback teams: %w", err)
+	}
+	for position, fallbackTeam := range fallbackTeams {
+		if _, err := conn(ctx, r.db).ExecContext(ctx, queryAddFallbackTeam, teamName, position, fallbackTeam); err != nil {
+			return fmt.Errorf("add fallback team: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// GetFallbackTeams получает резервные команды команды по порядку
+func (r *TeamRepository) GetFallbackTeams(ctx context.Context, teamName string) ([]string, error) {
+	rows, err := conn(ctx, r.db).QueryContext(ctx, queryGetFallbackTeams, teamName)
+	if err != nil {
+		return nil, fmt.Errorf("get fallback teams: %w", err)
+	}
+	defer rows.Close()
+
+	fallbackTeams := []string{}
+	for rows.Next() {
+		var fallbackTeam string
+		if err := rows.Scan(&fallbackTeam); err != nil {
+			return nil, fmt.Errorf("scan fallback team: %w", err)
+		}
+		fallbackTeams = append(fallbackTeams, fallbackTeam)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate fallback teams: %w", err)
+	}
+
+	return fallbackTeams, nil
+}
+
+// SetCodeOwnerRules заменяет правила владельцев кода команды, сохраняя их порядок; владельцы хранятся в JSON
+func (r *TeamRepository) SetCodeOwnerRules(ctx context.Context, teamName string, rules []entity.CodeOwnerRule) error {
+	if _, err := conn(ctx, r.db).ExecContext(ctx, queryDeleteCodeOwnerRules, teamName); err != nil {
+		return fmt.Errorf("delete code owner rules: %w", err)
+	}
+	for position, rule := range rules {
+		owners, err := json.Marshal(rule.Owners)
+		if err != nil {
+			return fmt.Errorf("encode code owners: %w", err)
+		}
+		if _, err := conn(ctx, r.db).ExecContext(ctx, queryAddCodeOwnerRule, teamName, position, rule.Pattern, string(owners)); err != nil {
+			return fmt.Errorf("add code owner rule: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// GetCodeOwnerRules получает правила владельцев кода команды по порядку
+func (r *TeamRepository) GetCodeOwnerRules(ctx context.Context, teamName string) ([]entity.CodeOwnerRule, error) {
+	rows, err := conn(ctx, r.db).QueryContext(ctx, queryGetCodeOwnerRules, teamName)
+	if err != nil {
+		return nil, fmt.Errorf("get code owner rules: %w", err)
+	}
+	defer rows.Close()
+
+	rules := []entity.CodeOwnerRule{}
+	for rows.Next() {
+		var (
+			rule   entity.CodeOwnerRule
+			owners string
+		)
+		if err := rows.Scan(&rule.Pattern, &owners); err != nil {
+			return nil, fmt.Errorf("scan code owner rule: %w", err)
+		}
+		if err := json.Unmarshal([]byte(owners), &rule.Owners); err != nil {
+			return nil, fmt.Errorf("decode code owners: %w", err)
+		}
+		rules = append(rules, rule)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate code owner rules: %w", err)
+	}
+
+	return rules, nil
+}
@@ -0,0 +1,283 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"internship/internal/domain/entity"
+)
+
+const (
+	queryCreateOrUpdateUser = `
+		INSERT INTO users (user_id, username, team_name, is_active, max_open_reviews, expertise_tags)
+		VALUES (?, ?, ?, ?, ?, ?)
+		ON CONFLICT (user_id) DO UPDATE SET
+			username = excluded.username,
+			team_name = excluded.team_name,
+			is_active = excluded.is_active,
+			max_open_reviews = excluded.max_open_reviews,
+			expertise_tags = excluded.expertise_tags
+	`
+
+	querySetIsActive = `
+		UPDATE users
+		SET is_active = ?
+		WHERE user_id = ?
+	`
+
+	queryUpdate = `
+		UPDATE users
+		SET username = ?, team_name = ?, is_active = ?, max_open_reviews = ?, expertise_tags = ?
+		WHERE user_id = ?
+	`
+
+	querySetExpertiseTags = `
+		UPDATE users
+		SET expertise_tags = ?
+		WHERE user_id = ?
+	`
+
+	queryDeactivateTeamMembers = `
+		UPDATE users
+		SET is_active = 0
+		WHERE team_name = ?
+	`
+
+	queryGetByID = `
+		SELECT user_id, username, team_name, is_active, max_open_reviews, expertise_tags
+		FROM users
+		WHERE user_id = ?
+	`
+
+	queryGetByTeamName = `
+		SELECT user_id, username, team_name, is_active, max_open_reviews, expertise_tags
+		FROM users
+		WHERE team_name = ?
+		ORDER BY user_id
+	`
+)
+
+type UserRepository struct {
+	db *sql.DB
+}
+
+func NewUserRepository(db *sql.DB) *UserRepository {
+	return &UserRepository{db: db}
+}
+
+// BatchCreateOrUpdate создает или обновляет пользователей
+func (r *UserRepository) BatchCreateOrUpdate(ctx context.Context, users []*entity.User) error {
+	return runInTx(ctx, r.db, func(ctx context.Context) error {
+		q := conn(ctx, r.db)
+		for _, user := range users {
+			tags, err := encodeStrings(user.ExpertiseTags)
+			if err != nil {
+				return fmt.Errorf("encode expertise tags of user %s: %w", user.UserID, err)
+			}
+			_, err = q.ExecContext(ctx, queryCreateOrUpdateUser,
+				user.UserID,
+				user.Username,
+				user.TeamName,
+				user.IsActive,
+				user.MaxOpenReviews,
+				tags,
+			)
+			if err != nil {
+				return fmt.Errorf("update user %s: %w", user.UserID, err)
+			}
+		}
+
+		return nil
+	})
+}
+
+// Update обновляет данные пользователя
+func (r *UserRepository) Update(ctx context.Context, user *entity.User) error {
+	tags, err := encodeStrings(user.ExpertiseTags)
+	if err != nil {
+		return fmt.Errorf("update user: encode expertise tags: %w", err)
+	}
+
+	result, err := conn(ctx, r.db).ExecContext(ctx, queryUpdate,
+		user.Username,
+		user.TeamName,
+		user.IsActive,
+		user.MaxOpenReviews,
+		tags,
+		user.UserID,
+	)
+	if err != nil {
+		return fmt.Errorf("update user: %w", err)
+	}
+
+	return userAffected(result)
+}
+
+// GetByID получает пользователя по ID
+func (r *UserRepository) GetByID(ctx context.Context, userID string) (*entity.User, error) {
+	var user entity.User
+	err := scanUser(conn(ctx, r.db).QueryRowContext(ctx, queryGetByID, userID), &user)
+
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, entity.ErrUserNotFound
+		}
+		return nil, fmt.Errorf("get user by id: %w", err)
+	}
+
+	return &user, nil
+}
+
+// GetByTeamName получает всех пользователей команды
+func (r *UserRepository) GetByTeamName(ctx context.Context, teamName string) ([]entity.User, error) {
+	rows, err := conn(ctx, r.db).QueryContext(ctx, queryGetByTeamName, teamName)
+	if err != nil {
+		return nil, fmt.Errorf("get users by team: %w", err)
+	}
+	defer rows.Close()
+
+	var users []entity.User
+	for rows.Next() {
+		var user entity.User
+		if err := scanUser(rows, &user); err != nil {
+			return nil, fmt.Errorf("scan user: %w", err)
+		}
+		users = append(users, user)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate users: %w", err)
+	}
+
+	return users, nil
+}
+
+// SetIsActive устанавливает флаг активности пользователя
+func (r *UserRepository) SetIsActive(ctx context.Context, userID string, isActive bool) error {
+	result, err := conn(ctx, r.db).ExecContext(ctx, querySetIsActive, isActive, userID)
+	if err != nil {
+		return fmt.Errorf("set is_active: %w", err)
+	}
+
+	return userAffected(result)
+}
+
+// SetExpertiseTags заменяет теги экспертизы пользователя
+func (r *UserRepository) SetExpertiseTags(ctx context.Context, userID string, tags []string) error {
+	encoded, err := encodeStrings(tags)
+	if err != nil {
+		return fmt.Errorf("set expertise tags: %w", err)
+	}
+
+	result, err := conn(ctx, r.db).ExecContext(ctx, querySetExpertiseTags, encoded, userID)
+	if err != nil {
+		return fmt.Errorf("set expertise tags: %w", err)
+	}
+
+	return userAffected(result)
+}
+
+// DeactivateTeamMembers деактивирует всех участников команды и применяет замены ревьюверов
+// открытых PR в одной транзакции
+func (r *UserRepository) DeactivateTeamMembers(ctx context.Context, teamName string, reassignments []entity.ReviewerReassignment) error {
+	err := runInTx(ctx, r.db, func(ctx context.Context) error {
+		q := conn(ctx, r.db)
+		if _, err := q.ExecContext(ctx, queryDeactivateTeamMembers, teamName); err != nil {
+			return fmt.Errorf("deactivate team members: %w", err)
+		}
+
+		// Запросы подготавливаются один раз на все PR
+		deleteReviewer, err := q.PrepareContext(ctx, queryDeleteReviewer)
+		if err != nil {
+			return fmt.Errorf("prepare delete reviewer: %w", err)
+		}
+		defer deleteReviewer.Close()
+		insertReviewer, err := q.PrepareContext(ctx, queryInsertReviewer)
+		if err != nil {
+			return fmt.Errorf("prepare insert reviewer: %w", err)
+		}
+		defer insertReviewer.Close()
+		setNeedMoreReviewers, err := q.PrepareContext(ctx, querySetNeedMoreReviewers)
+		if err != nil {
+			return fmt.Errorf("prepare update pr: %w", err)
+		}
+		defer setNeedMoreReviewers.Close()
+
+		for _, reassignment := range reassignments {
+			prID := reassignment.PullRequest.PullRequestID
+			for _, userID := range reassignment.RemovedReviewers {
+				if _, err := deleteReviewer.ExecContext(ctx, prID, userID); err != nil {
+					return fmt.Errorf("reassign reviewers of pr %s: %w", prID, err)
+				}
+			}
+			for _, userID := range reassignment.AddedReviewers {
+				if _, err := insertReviewer.ExecContext(ctx, prID, userID, now()); err != nil {
+					return fmt.Errorf("reassign reviewers of pr %s: %w", prID, err)
+				}
+			}
+
+			// PR изменен параллельно после чтения — откатываем всю деактивацию
+			result, err := setNeedMoreReviewers.ExecContext(ctx,
+				reassignment.PullRequest.NeedMoreReviewers,
+				prID,
+				reassignment.PullRequest.Version,
+			)
+			if err != nil {
+				return fmt.Errorf("update pr %s: %w", prID, err)
+			}
+			affected, err := result.RowsAffected()
+			if err != nil {
+				return fmt.Errorf("update pr %s: %w", prID, err)
+			}
+			if affected == 0 {
+				return fmt.Errorf("update pr %s: %w", prID, entity.ErrVersionConflict)
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	for i := range reassignments {
+		reassignments[i].PullRequest.Version++
+	}
+	return nil
+}
+
+// userAffected возвращает ErrUserNotFound, если запрос не изменил ни одной строки
+func userAffected(result sql.Result) error {
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("get affected rows: %w", err)
+	}
+	if affected == 0 {
+		return entity.ErrUserNotFound
+	}
+
+	return nil
+}
+
+// scanUser читает строку пользователя; теги экспертизы хранятся в JSON
+func scanUser(row interface{ Scan(dest ...any) error }, user *entity.User) error {
+	var tags string
+	err := row.Scan(
+		&user.UserID,
+		&user.Username,
+		&user.TeamName,
+		&user.IsActive,
+		&user.MaxOpenReviews,
+		&tags,
+	)
+	if err != nil {
+		return err
+	}
+
+	if err := json.Unmarshal([]byte(tags), &user.ExpertiseTags); err != nil {
+		return fmt.Errorf("decode expertise tags: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,36 @@
+package service
+
+import (
+	"context"
+	"internship/internal/domain/entity"
+)
+
+// newAssignmentEvent создает событие истории назначений от имени инициатора операции из контекста
+func newAssignmentEvent(
+	ctx context.Context,
+	prID string,
+	eventType entity.AssignmentEventType,
+	reason entity.AssignmentReason,
+	userID string,
+) entity.ReviewerAssignmentEvent {
+	return entity.ReviewerAssignmentEvent{
+		PullRequestID: prID,
+		Event:         eventType,
+		UserID:        userID,
+		Reason:        reason,
+		Actor:         entity.ActorFromContext(ctx),
+	}
+}
+
+// reassignmentEvents описывает замену состава ревьюверов PR событиями снятия и назначения
+func reassignmentEvents(ctx context.Context, reassignment entity.ReviewerReassignment, reason entity.AssignmentReason) []entity.ReviewerAssignmentEvent {
+	prID := reassignment.PullRequest.PullRequestID
+	events := make([]entity.ReviewerAssignmentEvent, 0, len(reassignment.RemovedReviewers)+len(reassignment.AddedReviewers))
+	for _, userID := range reassignment.RemovedReviewers {
+		events = append(events, newAssignmentEvent(ctx, prID, entity.AssignmentEventUnassign, reason, userID))
+	}
+	for _, userID := range reassignment.AddedReviewers {
+		events = append(events, newAssignmentEvent(ctx, prID, entity.AssignmentEventAssign, reason, userID))
+	}
+	return events
+}
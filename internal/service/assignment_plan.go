@@ -0,0 +1,154 @@
+package service
+
+import (
+	"context"
+	"internship/internal/domain/entity"
+)
+
+// assignmentPlan — состояние операции, которая подбирает ревьюверов на несколько PR и записывает назначения в конце.
+// Команды, их участники, резервные команды, правила владельцев кода и число открытых ревью читаются из хранилища
+// один раз за операцию. Назначения, запланированные для предыдущих PR, сразу добавляются к нагрузке,
+// поэтому лимиты открытых ревью и стратегия least_loaded учитывают их до записи в хранилище
+type assignmentPlan struct {
+	loads          map[string]int
+	teams          map[string]*entity.Team
+	members        map[string][]entity.User
+	fallbackTeams  map[string][]string
+	codeOwnerRules map[string][]entity.CodeOwnerRule
+	users          map[string]*entity.User
+}
+
+// planKey — ключ контекста, под которым хранится план назначений операции
+type planKey struct{}
+
+// withAssignmentPlan возвращает контекст с новым планом назначений
+func withAssignmentPlan(ctx context.Context) context.Context {
+	return context.WithValue(ctx, planKey{}, &assignmentPlan{
+		loads:          make(map[string]int),
+		teams:          make(map[string]*entity.Team),
+		members:        make(map[string][]entity.User),
+		fallbackTeams:  make(map[string][]string),
+		codeOwnerRules: make(map[string][]entity.CodeOwnerRule),
+		users:          make(map[string]*entity.User),
+	})
+}
+
+func planFromContext(ctx context.Context) *assignmentPlan {
+	plan, _ := ctx.Value(planKey{}).(*assignmentPlan)
+	return plan
+}
+
+// countOpenReviews возвращает число открытых ревью пользователей. Внутри плана назначений
+// из хранилища читаются только пользователи, которых план еще не видел, а к нагрузке добавлены запланированные назначения
+func countOpenReviews(ctx context.Context, reviewerRepo ReviewerRepositoryInterface, userIDs []string) (map[string]int, error) {
+	plan := planFromContext(ctx)
+	if plan == nil {
+		return reviewerRepo.CountOpenReviews(ctx, userIDs)
+	}
+
+	var missing []string
+	for _, userID := range userIDs {
+		if _, ok := plan.loads[userID]; !ok {
+			missing = append(missing, userID)
+		}
+	}
+	if len(missing) > 0 {
+		loads, err := reviewerRepo.CountOpenReviews(ctx, missing)
+		if err != nil {
+			return nil, err
+		}
+		for _, userID := range missing {
+			plan.loads[userID] = loads[userID]
+		}
+	}
+
+	counts := make(map[string]int, len(userIDs))
+	for _, userID := range userIDs {
+		counts[userID] = plan.loads[userID]
+	}
+	return counts, nil
+}
+
+// planAssignments добавляет к нагрузке плана назначения, которые будут записаны в конце операции.
+// Вне плана назначения пишутся сразу и учитываются хранилищем
+func planAssignments(ctx context.Context, reviewerRepo ReviewerRepositoryInterface, userIDs []string) error {
+	plan := planFromContext(ctx)
+	if plan == nil || len(userIDs) == 0 {
+		return nil
+	}
+
+	if _, err := countOpenReviews(ctx, reviewerRepo, userIDs); err != nil {
+		return err
+	}
+	for _, userID := range userIDs {
+		plan.loads[userID]++
+	}
+	return nil
+}
+
+// loadOnce возвращает значение из кэша плана, загружая его при первом обращении.
+// Вне плана значение загружается при каждом обращении; ошибки загрузки не кэшируются
+func loadOnce[V any](ctx context.Context, cache func(*assignmentPlan) map[string]V, key string, load func() (V, error)) (V, error) {
+	plan := planFromContext(ctx)
+	if plan == nil {
+		return load()
+	}
+
+	values := cache(plan)
+	if value, ok := values[key]; ok {
+		return value, nil
+	}
+	value, err := load()
+	if err != nil {
+		return value, err
+	}
+	values[key] = value
+	return value, nil
+}
+
+// team возвращает команду по имени
+func (a *reviewerAssigner) team(ctx context.Context, teamName string) (*entity.Team, error) {
+	return loadOnce(ctx, func(p *assignmentPlan) map[string]*entity.Team { return p.teams }, teamName, func() (*entity.Team, error) {
+		return a.teamRepo.GetByName(ctx, teamName)
+	})
+}
+
+// teamMembers возвращает участников команды. Внутри плана участники попадают и в кэш пользователей,
+// чтобы авторов и владельцев кода из уже прочитанных команд не запрашивать по одному
+func (a *reviewerAssigner) teamMembers(ctx context.Context, teamName string) ([]entity.User, error) {
+	return loadOnce(ctx, func(p *assignmentPlan) map[string][]entity.User { return p.members }, teamName, func() ([]entity.User, error) {
+		members, err := a.userRepo.GetByTeamName(ctx, teamName)
+		if err != nil {
+			return nil, err
+		}
+		if plan := planFromContext(ctx); plan != nil {
+			for i := range members {
+				if _, ok := plan.users[members[i].UserID]; !ok {
+					plan.users[members[i].UserID] = &members[i]
+				}
+			}
+		}
+		return members, nil
+	})
+}
+
+// fallbackTeams возвращает резервные команды команды
+func (a *reviewerAssigner) fallbackTeams(ctx context.Context, teamName string) ([]string, error) {
+	return loadOnce(ctx, func(p *assignmentPlan) map[string][]string { return p.fallbackTeams }, teamName, func() ([]string, error) {
+		return a.teamRepo.GetFallbackTeams(ctx, teamName)
+	})
+}
+
+// codeOwnerRules возвращает правила владельцев кода команды
+func (a *reviewerAssigner) codeOwnerRules(ctx context.Context, teamName string) ([]entity.CodeOwnerRule, error) {
+	return loadOnce(ctx, func(p *assignmentPlan) map[string][]entity.CodeOwnerRule { return p.codeOwnerRules }, teamName, func() ([]entity.CodeOwnerRule, error) {
+		return a.teamRepo.GetCodeOwnerRules(ctx, teamName)
+	})
+}
+
+// user возвращает пользователя по ID
+func (a *reviewerAssigner) user(ctx context.Context, userID string) (*entity.User, error) {
+	return loadOnce(ctx, func(p *assignmentPlan) map[string]*entity.User { return p.users }, userID, func() (*entity.User, error) {
+		return a.userRepo.GetByID(ctx, userID)
+	})
+}
@@ -0,0 +1,80 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"internship/internal/domain/entity"
+
+	"go.uber.org/zap"
+)
+
+// auditChange — изменение сущности, которое нужно записать в журнал аудита.
+// Before и After сериализуются в JSON; nil означает, что сущность не существовала
+type auditChange struct {
+	action     entity.AuditAction
+	entityType entity.AuditEntityType
+	entityID   string
+	before     any
+	after      any
+}
+
+// auditLog записывает изменения сущностей в журнал аудита от имени инициатора запроса.
+// Запись выполняется в транзакции изменения, поэтому журнал не расходится с данными
+type auditLog struct {
+	repo AuditRepositoryInterface
+	log  *zap.Logger
+}
+
+func newAuditLog(repo AuditRepositoryInterface, log *zap.Logger) *auditLog {
+	return &auditLog{repo: repo, log: log}
+}
+
+// snapshot фиксирует состояние сущности до изменения: сущность может измениться на месте
+func (a *auditLog) snapshot(state any) (json.RawMessage, error) {
+	raw, err := json.Marshal(state)
+	if err != nil {
+		a.log.Error("encode audit state", zap.Error(err))
+		return nil, fmt.Errorf("encode audit state: %w", err)
+	}
+	return raw, nil
+}
+
+// record записывает изменения в журнал аудита.
+// Операции, не изменившие состояние сущности (например, повторный merge), не записываются
+func (a *auditLog) record(ctx context.Context, changes ...auditChange) error {
+	records := make([]entity.AuditRecord, 0, len(changes))
+	for _, change := range changes {
+		before, err := a.snapshot(change.before)
+		if err != nil {
+			return err
+		}
+		after, err := a.snapshot(change.after)
+		if err != nil {
+			return err
+		}
+		if bytes.Equal(before, after) {
+			continue
+		}
+
+		records = append(records, entity.AuditRecord{
+			Action:     change.action,
+			EntityType: change.entityType,
+			EntityID:   change.entityID,
+			Actor:      entity.ActorFromContext(ctx),
+			RequestID:  entity.RequestIDFromContext(ctx),
+			Before:     before,
+			After:      after,
+		})
+	}
+	if len(records) == 0 {
+		return nil
+	}
+
+	if err := a.repo.AddRecords(ctx, records); err != nil {
+		a.log.Error("add audit records", zap.Error(err))
+		return fmt.Errorf("add audit records: %w", err)
+	}
+	return nil
+}
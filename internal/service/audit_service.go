@@ -0,0 +1,69 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"internship/internal/domain/entity"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// Размер страницы журнала аудита
+const (
+	defaultAuditLimit = 100
+	maxAuditLimit     = 1000
+)
+
+type AuditService struct {
+	auditRepo AuditRepositoryInterface
+	retention time.Duration
+	log       *zap.Logger
+}
+
+// NewAuditService создает сервис журнала аудита.
+// retention — срок хранения записей, 0 — записи хранятся бессрочно
+func NewAuditService(auditRepo AuditRepositoryInterface, retention time.Duration, log *zap.Logger) *AuditService {
+	return &AuditService{
+		auditRepo: auditRepo,
+		retention: retention,
+		log:       log,
+	}
+}
+
+// ListRecords возвращает записи журнала аудита по фильтру от новых к старым.
+// Без лимита возвращается defaultAuditLimit записей, лимит больше maxAuditLimit отклоняется
+func (s *AuditService) ListRecords(ctx context.Context, filter entity.AuditFilter) ([]entity.AuditRecord, error) {
+	if filter.Limit == 0 {
+		filter.Limit = defaultAuditLimit
+	}
+	if filter.Limit < 0 || filter.Limit > maxAuditLimit {
+		return nil, fmt.Errorf("%w: limit must be between 1 and %d", entity.ErrInvalidInput, maxAuditLimit)
+	}
+	if filter.From != nil && filter.To != nil && !filter.From.Before(*filter.To) {
+		return nil, fmt.Errorf("%w: from must be before to", entity.ErrInvalidInput)
+	}
+
+	records, err := s.auditRepo.ListRecords(ctx, filter)
+	if err != nil {
+		s.log.Error("list audit records", zap.Error(err))
+		return nil, fmt.Errorf("list audit records: %w", err)
+	}
+
+	return records, nil
+}
+
+// PurgeExpired удаляет записи старше срока хранения и возвращает их число
+func (s *AuditService) PurgeExpired(ctx context.Context) (int64, error) {
+	if s.retention <= 0 {
+		return 0, nil
+	}
+
+	deleted, err := s.auditRepo.DeleteBefore(ctx, time.Now().Add(-s.retention))
+	if err != nil {
+		s.log.Error("delete expired audit records", zap.Error(err))
+		return 0, fmt.Errorf("delete expired audit records: %w", err)
+	}
+
+	return deleted, nil
+}
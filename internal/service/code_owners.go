@@ -0,0 +1,214 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"internship/internal/domain/entity"
+	"path"
+	"strings"
+	"unicode/utf8"
+)
+
+// Ограничения измененных файлов PR и шаблонов правил владельцев кода
+const (
+	maxChangedFiles   = 1000
+	maxPathLength     = 1024
+	maxPatternLength  = 1024
+	codeOwnerWildcard = "**"
+)
+
+// validateChangedFiles проверяет пути измененных файлов PR
+func validateChangedFiles(files []string) error {
+	if len(files) > maxChangedFiles {
+		return fmt.Errorf("%w: at most %d changed files allowed", entity.ErrInvalidInput, maxChangedFiles)
+	}
+
+	seen := make(map[string]bool, len(files))
+	for _, file := range files {
+		if strings.Trim(strings.TrimSpace(file), "/") == "" {
+			return fmt.Errorf("%w: changed file paths must not be empty", entity.ErrInvalidInput)
+		}
+		if utf8.RuneCountInString(file) > maxPathLength {
+			return fmt.Errorf("%w: changed file path must be at most %d characters", entity.ErrInvalidInput, maxPathLength)
+		}
+		if seen[file] {
+			return fmt.Errorf("%w: duplicate changed file %q", entity.ErrInvalidInput, file)
+		}
+		seen[file] = true
+	}
+	return nil
+}
+
+// validateCodeOwnerPattern проверяет синтаксис шаблона правила владельцев кода
+func validateCodeOwnerPattern(pattern string) error {
+	if strings.Trim(strings.TrimSpace(pattern), "/") == "" {
+		return fmt.Errorf("%w: pattern must not be empty", entity.ErrInvalidInput)
+	}
+	if utf8.RuneCountInString(pattern) > maxPatternLength {
+		return fmt.Errorf("%w: pattern must be at most %d characters", entity.ErrInvalidInput, maxPatternLength)
+	}
+	for _, segment := range strings.Split(strings.Trim(pattern, "/"), "/") {
+		if _, err := path.Match(segment, ""); err != nil {
+			return fmt.Errorf("%w: invalid pattern %q", entity.ErrInvalidInput, pattern)
+		}
+	}
+	return nil
+}
+
+// matchCodeOwnerPattern проверяет, совпадает ли шаблон с файлом или с одним из каталогов на пути к нему
+func matchCodeOwnerPattern(pattern, file string) bool {
+	dirOnly := strings.HasSuffix(pattern, "/")
+	pattern = strings.TrimSuffix(pattern, "/")
+	// Шаблон без "/" совпадает с именем на любой глубине, как в CODEOWNERS
+	anchored := strings.Contains(pattern, "/")
+	patternSegments := strings.Split(strings.TrimPrefix(pattern, "/"), "/")
+	if !anchored {
+		patternSegments = append([]string{codeOwnerWildcard}, patternSegments...)
+	}
+
+	fileSegments := strings.Split(strings.Trim(file, "/"), "/")
+	for n := len(fileSegments); n > 0; n-- {
+		// Сам файл не является каталогом
+		if dirOnly && n == len(fileSegments) {
+			continue
+		}
+		if matchSegments(patternSegments, fileSegments[:n]) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchSegments сопоставляет сегменты шаблона сегментам пути; "**" совпадает с любым числом сегментов
+func matchSegments(pattern, segments []string) bool {
+	if len(pattern) == 0 {
+		return len(segments) == 0
+	}
+	if pattern[0] == codeOwnerWildcard {
+		for i := 0; i <= len(segments); i++ {
+			if matchSegments(pattern[1:], segments[i:]) {
+				return true
+			}
+		}
+		return false
+	}
+	if len(segments) == 0 {
+		return false
+	}
+	matched, err := path.Match(pattern[0], segments[0])
+	return err == nil && matched && matchSegments(pattern[1:], segments[1:])
+}
+
+// matchCodeOwners возвращает владельцев кода измененных файлов в порядке файлов и шаблон правила,
+// по которому найден каждый владелец. Для файла действует последнее совпавшее правило
+func matchCodeOwners(rules []entity.CodeOwnerRule, files []string) ([]string, map[string]string) {
+	owners := []string{}
+	patterns := make(map[string]string)
+	for _, file := range files {
+		for i := len(rules) - 1; i >= 0; i-- {
+			if !matchCodeOwnerPattern(rules[i].Pattern, file) {
+				continue
+			}
+			for _, ownerID := range rules[i].Owners {
+				if _, ok := patterns[ownerID]; !ok {
+					owners = append(owners, ownerID)
+					patterns[ownerID] = rules[i].Pattern
+				}
+			}
+			break
+		}
+	}
+	return owners, patterns
+}
+
+// pickCodeOwners выбирает до count ревьюверов среди владельцев измененных файлов PR по правилам команды автора.
+// Владелец должен быть активен, не исключен и не достичь лимита открытых ревью; выбирает стратегия команды автора
+// с учетом требуемых тегов PR. Выбранные пользователи добавляются в excluded.
+// Возвращает также шаблоны правил, по которым найдены выбранные
+func (a *reviewerAssigner) pickCodeOwners(ctx context.Context, authorTeam string, pr *entity.PullRequest, excluded map[string]bool, count int) ([]entity.User, map[string]string, error) {
+	if count <= 0 || len(pr.ChangedFiles) == 0 {
+		return []entity.User{}, nil, nil
+	}
+
+	rules, err := a.codeOwnerRules(ctx, authorTeam)
+	if err != nil {
+		return nil, nil, fmt.Errorf("get code owner rules: %w", err)
+	}
+	ownerIDs, patterns := matchCodeOwners(rules, pr.ChangedFiles)
+
+	candidates := make([]entity.User, 0, len(ownerIDs))
+	for _, ownerID := range ownerIDs {
+		if excluded[ownerID] {
+			continue
+		}
+		owner, err := a.user(ctx, ownerID)
+		if errors.Is(err, entity.ErrUserNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, nil, fmt.Errorf("get code owner: %w", err)
+		}
+		if !owner.IsActive {
+			continue
+		}
+
+		available, _, err := a.filterByCapacity(ctx, owner.TeamName, []entity.User{*owner})
+		if err != nil {
+			return nil, nil, fmt.Errorf("filter by capacity: %w", err)
+		}
+		candidates = append(candidates, available...)
+	}
+	if len(candidates) == 0 {
+		return []entity.User{}, nil, nil
+	}
+
+	selected, err := a.selectReviewers(ctx, authorTeam, candidates, pr.RequiredTags, count)
+	if err != nil {
+		return nil, nil, fmt.Errorf("select code owners: %w", err)
+	}
+
+	matched := make(map[string]string, len(selected))
+	for _, reviewer := range selected {
+		excluded[reviewer.UserID] = true
+		matched[reviewer.UserID] = patterns[reviewer.UserID]
+	}
+	return selected, matched, nil
+}
+
+// pickForPullRequest выбирает до count ревьюверов на PR: сначала владельцев измененных файлов,
+// затем недостающих — из команд pools по порядку; в обоих случаях предпочтение отдается совпадению с требуемыми тегами PR.
+// Возвращает также шаблоны правил выбранных владельцев и число кандидатов из pools, пропущенных из-за лимита открытых ревью
+func (a *reviewerAssigner) pickForPullRequest(
+	ctx context.Context,
+	pr *entity.PullRequest,
+	authorTeam string,
+	pools []string,
+	excluded map[string]bool,
+	count int,
+) ([]entity.User, map[string]string, int, error) {
+	owners, matched, err := a.pickCodeOwners(ctx, authorTeam, pr, excluded, count)
+	if err != nil {
+		return nil, nil, 0, err
+	}
+
+	others, atCapacity, err := a.pickFromTeams(ctx, pools, excluded, pr.RequiredTags, count-len(owners))
+	if err != nil {
+		return nil, nil, 0, err
+	}
+
+	return append(owners, others...), matched, atCapacity, nil
+}
+
+// setReviewerRules отмечает в ответе, по каким правилам владельцев кода назначены ревьюверы
+func setReviewerRules(pr *entity.PullRequest, matched map[string]string) {
+	if len(matched) == 0 {
+		return
+	}
+	if pr.ReviewerRules == nil {
+		pr.ReviewerRules = make(map[string]string, len(matched))
+	}
+	for userID, pattern := range matched {
+		pr.ReviewerRules[userID] = pattern
+	}
+}
@@ -0,0 +1,158 @@
+package service
+
+import (
+	"internship/internal/domain/entity"
+	"maps"
+	"slices"
+	"testing"
+)
+
+// TestMatchCodeOwnerPattern проверяет сопоставление шаблонов CODEOWNERS с путями измененных файлов
+func TestMatchCodeOwnerPattern(t *testing.T) {
+	tests := []struct {
+		pattern string
+		file    string
+		want    bool
+	}{
+		// Шаблон без "/" совпадает с именем на любой глубине
+		{pattern: "*.go", file: "main.go", want: true},
+		{pattern: "*.go", file: "internal/service/code_owners.go", want: true},
+		{pattern: "*.go", file: "README.md", want: false},
+		{pattern: "*.go", file: "main.go.orig", want: false},
+
+		// "/" в начале привязывает к корню, в конце — только к каталогу
+		{pattern: "/docs/", file: "docs/index.md", want: true},
+		{pattern: "/docs/", file: "docs/api/swagger.yaml", want: true},
+		{pattern: "/docs/", file: "internal/docs/index.md", want: false},
+		{pattern: "/docs/", file: "docs", want: false},
+
+		// "/" в середине тоже привязывает к корню, "**" заменяет любое число сегментов
+		{pattern: "docs/**/x", file: "docs/x", want: true},
+		{pattern: "docs/**/x", file: "docs/a/b/x", want: true},
+		{pattern: "docs/**/x", file: "docs/a/x/readme.md", want: true},
+		{pattern: "docs/**/x", file: "docs/a/xy", want: false},
+		{pattern: "docs/**/x", file: "src/docs/a/x", want: false},
+
+		{pattern: "**/foo", file: "foo", want: true},
+		{pattern: "**/foo", file: "a/b/foo", want: true},
+		{pattern: "**/foo", file: "a/foo/bar.go", want: true},
+		{pattern: "**/foo", file: "a/foobar", want: false},
+
+		// Каталог без привязки к корню на любой глубине
+		{pattern: "build/", file: "build/app", want: true},
+		{pattern: "build/", file: "cmd/build/app", want: true},
+		{pattern: "build/", file: "cmd/build", want: false},
+		{pattern: "build/", file: "builds/app", want: false},
+
+		// Вложенное имя без "/" совпадает и с каталогом, и с файлом
+		{pattern: "testdata", file: "internal/service/testdata/pr.json", want: true},
+		{pattern: "testdata", file: "internal/testdata", want: true},
+		{pattern: "testdata", file: "internal/testdata2/pr.json", want: false},
+		{pattern: "service/testdata", file: "internal/service/testdata/pr.json", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.pattern+" "+tt.file, func(t *testing.T) {
+			if got := matchCodeOwnerPattern(tt.pattern, tt.file); got != tt.want {
+				t.Fatalf("matchCodeOwnerPattern(%q, %q) = %t, want %t", tt.pattern, tt.file, got, tt.want)
+			}
+		})
+	}
+}
+
+// TestMatchSegments проверяет сопоставление сегментов шаблона сегментам пути
+func TestMatchSegments(t *testing.T) {
+	tests := []struct {
+		name     string
+		pattern  []string
+		segments []string
+		want     bool
+	}{
+		{name: "both empty", pattern: nil, segments: nil, want: true},
+		{name: "path longer than pattern", pattern: []string{"a"}, segments: []string{"a", "b"}, want: false},
+		{name: "star within one segment", pattern: []string{"*"}, segments: []string{"a", "b"}, want: false},
+		{name: "double star matches nothing", pattern: []string{"**"}, segments: nil, want: true},
+		{name: "double star matches many", pattern: []string{"a", "**", "d"}, segments: []string{"a", "b", "c", "d"}, want: true},
+		{name: "double star needs the tail", pattern: []string{"**", "d"}, segments: []string{"a", "c"}, want: false},
+		{name: "character class", pattern: []string{"v[0-9]"}, segments: []string{"v2"}, want: true},
+		{name: "malformed segment", pattern: []string{"["}, segments: []string{"["}, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := matchSegments(tt.pattern, tt.segments); got != tt.want {
+				t.Fatalf("matchSegments(%q, %q) = %t, want %t", tt.pattern, tt.segments, got, tt.want)
+			}
+		})
+	}
+}
+
+// TestMatchCodeOwners проверяет, что для файла действует последнее совпавшее правило,
+// а владельцы возвращаются без повторов в порядке файлов
+func TestMatchCodeOwners(t *testing.T) {
+	rules := []entity.CodeOwnerRule{
+		{Pattern: "*", Owners: []string{"alice"}},
+		{Pattern: "*.go", Owners: []string{"bob"}},
+		{Pattern: "/internal/repository/", Owners: []string{"carol", "dave"}},
+		{Pattern: "*_test.go", Owners: []string{"erin"}},
+	}
+
+	tests := []struct {
+		name     string
+		files    []string
+		owners   []string
+		patterns map[string]string
+	}{
+		{
+			name:     "catch-all rule",
+			files:    []string{"README.md"},
+			owners:   []string{"alice"},
+			patterns: map[string]string{"alice": "*"},
+		},
+		{
+			name:     "later rule overrides catch-all",
+			files:    []string{"cmd/main.go"},
+			owners:   []string{"bob"},
+			patterns: map[string]string{"bob": "*.go"},
+		},
+		{
+			name:     "directory rule overrides extension rule",
+			files:    []string{"internal/repository/sqlite/user_repository.go"},
+			owners:   []string{"carol", "dave"},
+			patterns: map[string]string{"carol": "/internal/repository/", "dave": "/internal/repository/"},
+		},
+		{
+			name:     "last rule wins inside the directory",
+			files:    []string{"internal/repository/sqlite/user_repository_test.go"},
+			owners:   []string{"erin"},
+			patterns: map[string]string{"erin": "*_test.go"},
+		},
+		{
+			name:     "owners in file order without duplicates",
+			files:    []string{"main.go", "README.md", "internal/service/user_service.go", "internal/repository/memory/storage.go"},
+			owners:   []string{"bob", "alice", "carol", "dave"},
+			patterns: map[string]string{"bob": "*.go", "alice": "*", "carol": "/internal/repository/", "dave": "/internal/repository/"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			owners, patterns := matchCodeOwners(rules, tt.files)
+			if !slices.Equal(owners, tt.owners) {
+				t.Fatalf("expected owners %v, got %v", tt.owners, owners)
+			}
+			if !maps.Equal(patterns, tt.patterns) {
+				t.Fatalf("expected patterns %v, got %v", tt.patterns, patterns)
+			}
+		})
+	}
+
+	// Более общее правило после узкого перекрывает его
+	owners, _ := matchCodeOwners([]entity.CodeOwnerRule{
+		{Pattern: "/docs/", Owners: []string{"dave"}},
+		{Pattern: "*.md", Owners: []string{"alice"}},
+	}, []string{"docs/index.md"})
+	if !slices.Equal(owners, []string{"alice"}) {
+		t.Fatalf("expected the later *.md rule to win, got %v", owners)
+	}
+}
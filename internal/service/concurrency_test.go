@@ -0,0 +1,169 @@
+package service_test
+
+import (
+	"errors"
+	"fmt"
+	"internship/internal/config"
+	"internship/internal/domain/entity"
+	"internship/internal/service"
+	"slices"
+	"sync"
+	"testing"
+)
+
+const (
+	raceTeam      = "race"
+	raceTeamSize  = 8
+	raceReassigns = 6
+	raceRounds    = 20
+)
+
+// newPullRequestService собирает сервис PR над хранилищем выбранного драйвера с командой race из raceTeamSize участников
+func newPullRequestService(t *testing.T, driver string) *service.PullRequestService {
+	t.Helper()
+
+	repos := newTestRepositories(t, driver)
+	repos.createTeam(t, &entity.Team{TeamName: raceTeam, ReviewerSettings: entity.DefaultReviewerSettings}, raceTeamSize)
+
+	return repos.pullRequestService(t)
+}
+
+// createRacePR создает PR автора race_u0 с автоматически назначенными ревьюверами
+func createRacePR(t *testing.T, svc *service.PullRequestService, prID string) *entity.PullRequest {
+	t.Helper()
+
+	pr, err := svc.CreatePullRequest(t.Context(), &entity.PullRequest{
+		PullRequestID:   prID,
+		PullRequestName: "Race " + prID,
+		AuthorID:        "race_u0",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(pr.AssignedReviewers) == 0 {
+		t.Fatalf("%s: no reviewers assigned", prID)
+	}
+	return pr
+}
+
+// checkReviewers проверяет, что ревьюверы PR не повторяются и среди них нет автора
+func checkReviewers(t *testing.T, pr *entity.PullRequest) {
+	t.Helper()
+
+	seen := make(map[string]bool, len(pr.AssignedReviewers))
+	for _, reviewerID := range pr.AssignedReviewers {
+		if seen[reviewerID] {
+			t.Errorf("%s: reviewer %s assigned twice: %v", pr.PullRequestID, reviewerID, pr.AssignedReviewers)
+		}
+		if reviewerID == pr.AuthorID {
+			t.Errorf("%s: author assigned as reviewer", pr.PullRequestID)
+		}
+		seen[reviewerID] = true
+	}
+}
+
+// TestConcurrentReassignAndMerge одновременно переназначает ревьюверов PR и сливает его.
+// Ни одно переназначение не должно продублировать ревьювера или изменить состав ревьюверов после merge
+func TestConcurrentReassignAndMerge(t *testing.T) {
+	for _, driver := range []string{config.DriverMemory, config.DriverSQLite} {
+		t.Run(driver, func(t *testing.T) {
+			svc := newPullRequestService(t, driver)
+
+			for round := range raceRounds {
+				created := createRacePR(t, svc, fmt.Sprintf("race_pr_%d", round))
+
+				var (
+					wg       sync.WaitGroup
+					mu       sync.Mutex
+					merged   *entity.PullRequest
+					mergeErr error
+				)
+				for i := range raceReassigns {
+					oldUserID := created.AssignedReviewers[i%len(created.AssignedReviewers)]
+					wg.Add(1)
+					go func() {
+						defer wg.Done()
+						pr, _, err := svc.ReassignReviewer(t.Context(), created.PullRequestID, oldUserID, "", nil)
+						switch {
+						case err == nil:
+							mu.Lock()
+							checkReviewers(t, pr)
+							mu.Unlock()
+						case errors.Is(err, entity.ErrPRMerged), errors.Is(err, entity.ErrNotAssigned), errors.Is(err, entity.ErrNoCandidate):
+						default:
+							t.Errorf("reassign %s: unexpected error: %v", oldUserID, err)
+						}
+					}()
+				}
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					merged, mergeErr = svc.MergePullRequest(t.Context(), created.PullRequestID, nil)
+				}()
+				wg.Wait()
+
+				if mergeErr != nil {
+					t.Fatalf("merge: %v", mergeErr)
+				}
+				checkReviewers(t, merged)
+
+				// Переназначение после merge должно быть отклонено, а состав ревьюверов — остаться прежним
+				_, _, err := svc.ReassignReviewer(t.Context(), created.PullRequestID, merged.AssignedReviewers[0], "", nil)
+				if !errors.Is(err, entity.ErrPRMerged) {
+					t.Fatalf("reassign after merge: expected %v, got %v", entity.ErrPRMerged, err)
+				}
+				final, err := svc.MergePullRequest(t.Context(), created.PullRequestID, nil)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if !slices.Equal(final.AssignedReviewers, merged.AssignedReviewers) {
+					t.Fatalf("reviewers changed after merge: %v, then %v", merged.AssignedReviewers, final.AssignedReviewers)
+				}
+			}
+		})
+	}
+}
+
+// TestConcurrentReassignVersionConflict одновременно переназначает ревьюверов с одной ожидаемой версией PR.
+// Проходит ровно одно переназначение, остальные получают ErrVersionConflict
+func TestConcurrentReassignVersionConflict(t *testing.T) {
+	for _, driver := range []string{config.DriverMemory, config.DriverSQLite} {
+		t.Run(driver, func(t *testing.T) {
+			svc := newPullRequestService(t, driver)
+
+			for round := range raceRounds {
+				created := createRacePR(t, svc, fmt.Sprintf("race_pr_%d", round))
+				version := created.Version
+
+				var (
+					wg        sync.WaitGroup
+					mu        sync.Mutex
+					succeeded int
+				)
+				for i := range raceReassigns {
+					oldUserID := created.AssignedReviewers[i%len(created.AssignedReviewers)]
+					wg.Add(1)
+					go func() {
+						defer wg.Done()
+						pr, _, err := svc.ReassignReviewer(t.Context(), created.PullRequestID, oldUserID, "", &version)
+						mu.Lock()
+						defer mu.Unlock()
+						switch {
+						case err == nil:
+							succeeded++
+							checkReviewers(t, pr)
+						case errors.Is(err, entity.ErrVersionConflict):
+						default:
+							t.Errorf("reassign %s: unexpected error: %v", oldUserID, err)
+						}
+					}()
+				}
+				wg.Wait()
+
+				if succeeded != 1 {
+					t.Fatalf("%s: expected exactly one reassign to succeed, got %d", created.PullRequestID, succeeded)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,158 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"internship/internal/config"
+	"internship/internal/domain/entity"
+	"internship/internal/service"
+	"runtime"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+const (
+	oldTeam       = "old"
+	oldTeamSize   = 4
+	spareTeam     = "spare"
+	spareTeamSize = 3
+	spareLimit    = 3
+	oldPRs        = 5
+	// deactivationAttempts — сколько раз тест повторяет деактивацию, прерванную таймаутом
+	deactivationAttempts = 3
+)
+
+// Параметры BenchmarkDeactivateTeamMembers: команда из deactivationTeamSize участников деактивируется
+// за deactivationTarget, замены подбираются из резервной команды из deactivationSpareSize участников
+const (
+	deactivationTeamSize  = 200
+	deactivationSpareSize = 10
+	deactivationTarget    = 100 * time.Millisecond
+)
+
+// deactivationPRCounts — числа открытых PR деактивируемой команды в BenchmarkDeactivateTeamMembers
+var deactivationPRCounts = []int{10, 100, 300}
+
+// newDeactivationFixture создает команду old из teamSize участников с prCount открытыми PR, авторы которых
+// чередуются по участникам, и резервную команду spare из spareSize участников с лимитом spareLimit открытых ревью
+func newDeactivationFixture(tb testing.TB, driver string, teamSize, prCount, spareSize, spareLimit int) (testRepositories, *service.UserService) {
+	tb.Helper()
+
+	repos := newTestRepositories(tb, driver)
+	repos.createTeam(tb, &entity.Team{TeamName: oldTeam, ReviewerSettings: entity.DefaultReviewerSettings}, teamSize)
+	repos.createTeam(tb, &entity.Team{TeamName: spareTeam, ReviewerSettings: entity.DefaultReviewerSettings, DefaultMaxOpenReviews: &spareLimit}, spareSize)
+
+	prService := repos.pullRequestService(tb)
+	for i := range prCount {
+		pr, err := prService.CreatePullRequest(tb.Context(), &entity.PullRequest{
+			PullRequestID:   fmt.Sprintf("old_pr_%d", i),
+			PullRequestName: fmt.Sprintf("Old PR %d", i),
+			AuthorID:        memberID(oldTeam, i%teamSize),
+		})
+		if err != nil {
+			tb.Fatal(err)
+		}
+		if len(pr.AssignedReviewers) != entity.DefaultReviewerSettings.MaxReviewers {
+			tb.Fatalf("%s: expected %d reviewers, got %v", pr.PullRequestID, entity.DefaultReviewerSettings.MaxReviewers, pr.AssignedReviewers)
+		}
+	}
+
+	userService := service.NewUserService(
+		repos.user, repos.team, repos.pr, repos.reviewer, repos.event, repos.audit,
+		repos.selector(tb), repos.txManager, spareTeam, zap.NewNop(),
+	)
+	return repos, userService
+}
+
+// TestDeactivateTeamMembersRespectsCapacity деактивирует команду, все ревьюверы которой заменяются из резервной команды.
+// Замены, выбранные для предыдущих PR, должны учитываться в лимите открытых ревью и в нагрузке least_loaded
+func TestDeactivateTeamMembersRespectsCapacity(t *testing.T) {
+	for _, driver := range []string{config.DriverMemory, config.DriverSQLite, config.DriverPostgres} {
+		t.Run(driver, func(t *testing.T) {
+			repos, userService := newDeactivationFixture(t, driver, oldTeamSize, oldPRs, spareTeamSize, spareLimit)
+
+			// Укладываться в 100ms проверяет бенчмарк, а под детектором гонок SQLite может не успеть.
+			// Прерванная таймаутом деактивация откатывается целиком, поэтому ее можно повторить
+			var (
+				reassignments []entity.ReviewerReassignment
+				err           error
+			)
+			for range deactivationAttempts {
+				start := time.Now()
+				reassignments, err = userService.DeactivateTeamMembers(t.Context(), oldTeam)
+				if err == nil || !errors.Is(err, context.DeadlineExceeded) && time.Since(start) < deactivationTarget {
+					break
+				}
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(reassignments) != oldPRs {
+				t.Fatalf("expected %d reassignments, got %d", oldPRs, len(reassignments))
+			}
+
+			spareIDs := make([]string, 0, spareTeamSize)
+			for i := range spareTeamSize {
+				spareIDs = append(spareIDs, memberID(spareTeam, i))
+			}
+			loads, err := repos.reviewer.CountOpenReviews(t.Context(), spareIDs)
+			if err != nil {
+				t.Fatal(err)
+			}
+			// Мест в резервной команде меньше, чем снятых ревьюверов, поэтому все участники заполняются до лимита
+			for _, userID := range spareIDs {
+				if loads[userID] != spareLimit {
+					t.Errorf("%s: expected %d open reviews, got %d (loads %v)", userID, spareLimit, loads[userID], loads)
+				}
+			}
+		})
+	}
+}
+
+// BenchmarkDeactivateTeamMembers измеряет деактивацию команды из deactivationTeamSize участников, все ревьюверы
+// открытых PR которой заменяются из резервной команды. Кроме времени на операцию сообщаются метрики цели 100ms:
+// max-ms — самая долгая деактивация и timeouts — число деактиваций, прерванных таймаутом deactivationTarget.
+// Для PostgreSQL бенчмарк запускается при заданной REPOTEST_POSTGRES_DSN
+func BenchmarkDeactivateTeamMembers(b *testing.B) {
+	for _, driver := range []string{config.DriverMemory, config.DriverSQLite, config.DriverPostgres} {
+		for _, prCount := range deactivationPRCounts {
+			b.Run(fmt.Sprintf("%s/users=%d/prs=%d", driver, deactivationTeamSize, prCount), func(b *testing.B) {
+				var (
+					slowest  time.Duration
+					timeouts int
+					repos    testRepositories
+				)
+				for b.Loop() {
+					b.StopTimer()
+					// Хранилище прошлой итерации закрывается, чтобы сборщик мусора не обходил его во время замера
+					if repos.close != nil {
+						repos.close()
+					}
+					// Лимит не достигается, но число открытых ревью все равно проверяется
+					var userService *service.UserService
+					repos, userService = newDeactivationFixture(b, driver, deactivationTeamSize, prCount, deactivationSpareSize, prCount)
+					runtime.GC()
+					b.StartTimer()
+
+					start := time.Now()
+					_, err := userService.DeactivateTeamMembers(b.Context(), oldTeam)
+					elapsed := time.Since(start)
+					slowest = max(slowest, elapsed)
+					switch {
+					case err == nil:
+					case errors.Is(err, context.DeadlineExceeded) || elapsed >= deactivationTarget:
+						// Драйвер может вернуть вместо DeadlineExceeded ошибку закрытой по таймауту транзакции
+						timeouts++
+					default:
+						b.Fatal(err)
+					}
+				}
+				b.ReportMetric(float64(slowest.Microseconds())/1000, "max-ms")
+				b.ReportMetric(float64(timeouts), "timeouts")
+			})
+		}
+	}
+}
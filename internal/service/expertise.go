@@ -0,0 +1,80 @@
+package service
+
+import (
+	"fmt"
+	"internship/internal/domain/entity"
+	"slices"
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+// Ограничения тегов экспертизы пользователей и требуемых тегов PR
+const (
+	maxTags      = 20
+	maxTagLength = 50
+)
+
+// normalizeTags проверяет теги и приводит их к нижнему регистру без пробелов по краям,
+// чтобы Go и go считались одной областью экспертизы. field — имя поля для сообщения об ошибке
+func normalizeTags(field string, tags []string) ([]string, error) {
+	if tags == nil {
+		return nil, nil
+	}
+	if len(tags) > maxTags {
+		return nil, fmt.Errorf("%w: at most %d %s allowed", entity.ErrInvalidInput, maxTags, field)
+	}
+
+	normalized := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		tag = strings.ToLower(strings.TrimSpace(tag))
+		if tag == "" {
+			return nil, fmt.Errorf("%w: %s must not be empty", entity.ErrInvalidInput, field)
+		}
+		if utf8.RuneCountInString(tag) > maxTagLength {
+			return nil, fmt.Errorf("%w: tag %q must be at most %d characters", entity.ErrInvalidInput, tag, maxTagLength)
+		}
+		if slices.Contains(normalized, tag) {
+			return nil, fmt.Errorf("%w: duplicate tag %q in %s", entity.ErrInvalidInput, tag, field)
+		}
+		normalized = append(normalized, tag)
+	}
+	return normalized, nil
+}
+
+// expertiseScore возвращает число требуемых тегов, которые есть среди тегов экспертизы пользователя
+func expertiseScore(user entity.User, requiredTags []string) int {
+	score := 0
+	for _, tag := range requiredTags {
+		if slices.Contains(user.ExpertiseTags, tag) {
+			score++
+		}
+	}
+	return score
+}
+
+// rankByExpertise разбивает кандидатов на группы с одинаковым числом совпавших тегов, от большего к меньшему.
+// Порядок кандидатов внутри группы сохраняется; без требуемых тегов все кандидаты образуют одну группу
+func rankByExpertise(candidates []entity.User, requiredTags []string) [][]entity.User {
+	if len(requiredTags) == 0 {
+		return [][]entity.User{candidates}
+	}
+
+	byScore := make(map[int][]entity.User)
+	for _, candidate := range candidates {
+		score := expertiseScore(candidate, requiredTags)
+		byScore[score] = append(byScore[score], candidate)
+	}
+
+	scores := make([]int, 0, len(byScore))
+	for score := range byScore {
+		scores = append(scores, score)
+	}
+	sort.Sort(sort.Reverse(sort.IntSlice(scores)))
+
+	tiers := make([][]entity.User, 0, len(scores))
+	for _, score := range scores {
+		tiers = append(tiers, byScore[score])
+	}
+	return tiers
+}
@@ -3,12 +3,24 @@ package service
 import (
 	"context"
 	"internship/internal/domain/entity"
+	"time"
 )
 
+// TransactionManager выполняет операции нескольких репозиториев атомарно.
+// Репозитории, вызванные с контекстом fn, работают в одной транзакции
+type TransactionManager interface {
+	WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
 type TeamRepositoryInterface interface {
 	Create(ctx context.Context, team *entity.Team) error
 	GetByName(ctx context.Context, teamName string) (*entity.Team, error)
 	Exists(ctx context.Context, teamName string) (bool, error)
+	UpdateReviewerSettings(ctx context.Context, teamName string, settings entity.ReviewerSettings) error
+	SetFallbackTeams(ctx context.Context, teamName string, fallbackTeams []string) error
+	GetFallbackTeams(ctx context.Context, teamName string) ([]string, error)
+	SetCodeOwnerRules(ctx context.Context, teamName string, rules []entity.CodeOwnerRule) error
+	GetCodeOwnerRules(ctx context.Context, teamName string) ([]entity.CodeOwnerRule, error)
 }
 
 // UserRepository определяет интерфейс для работы с пользователями
@@ -18,17 +30,20 @@ type UserRepositoryInterface interface {
 	GetByID(ctx context.Context, userID string) (*entity.User, error)
 	GetByTeamName(ctx context.Context, teamName string) ([]entity.User, error)
 	SetIsActive(ctx context.Context, userID string, isActive bool) error
-	DeactivateTeamMembers(ctx context.Context, teamName string) error
+	SetExpertiseTags(ctx context.Context, userID string, tags []string) error
+	DeactivateTeamMembers(ctx context.Context, teamName string, reassignments []entity.ReviewerReassignment) error
 }
 
 // PullRequestRepository определяет интерфейс для работы с PR
 type PullRequestRepositoryInterface interface {
 	Create(ctx context.Context, pr *entity.PullRequest) error
 	GetByID(ctx context.Context, prID string) (*entity.PullRequest, error)
+	GetByIDForUpdate(ctx context.Context, prID string) (*entity.PullRequest, error)
 	Update(ctx context.Context, pr *entity.PullRequest) error
 	Exists(ctx context.Context, prID string) (bool, error)
 	GetByReviewer(ctx context.Context, userID string) ([]entity.PullRequest, error)
 	GetOpenPRsByReviewers(ctx context.Context, reviewerIDs []string) ([]entity.PullRequest, error)
+	GetOpenNeedingReviewers(ctx context.Context) ([]entity.PullRequest, error)
 }
 
 // ReviewerRepository определяет интерфейс для работы с назначениями ревьюверов
@@ -38,6 +53,33 @@ type ReviewerRepositoryInterface interface {
 	GetReviewers(ctx context.Context, prID string) ([]string, error)
 	IsAssigned(ctx context.Context, prID, userID string) (bool, error)
 	ReplaceReviewer(ctx context.Context, prID, oldUserID, newUserID string) error
+	CountOpenReviews(ctx context.Context, userIDs []string) (map[string]int, error)
+}
+
+// ReviewDecisionRepository определяет интерфейс для работы с решениями ревьюверов
+type ReviewDecisionRepositoryInterface interface {
+	AddDecision(ctx context.Context, prID string, decision *entity.ReviewDecision) error
+	GetDecisions(ctx context.Context, prID string) ([]entity.ReviewDecision, error)
+}
+
+// PullRequestChangeRepository определяет интерфейс для работы с историей правок PR
+type PullRequestChangeRepositoryInterface interface {
+	AddChanges(ctx context.Context, prID string, changes []entity.PullRequestChange) error
+	GetChanges(ctx context.Context, prID string) ([]entity.PullRequestChange, error)
+}
+
+// AssignmentEventRepositoryInterface определяет интерфейс для истории назначений ревьюверов.
+// История только дополняется: события не изменяются и не удаляются
+type AssignmentEventRepositoryInterface interface {
+	AddEvents(ctx context.Context, events []entity.ReviewerAssignmentEvent) error
+	GetEvents(ctx context.Context, prID string) ([]entity.ReviewerAssignmentEvent, error)
+}
+
+// AuditRepositoryInterface определяет интерфейс для журнала аудита
+type AuditRepositoryInterface interface {
+	AddRecords(ctx context.Context, records []entity.AuditRecord) error
+	ListRecords(ctx context.Context, filter entity.AuditFilter) ([]entity.AuditRecord, error)
+	DeleteBefore(ctx context.Context, before time.Time) (int64, error)
 }
 
 // StatisticsRepository определяет интерфейс для получения статистики
@@ -0,0 +1,93 @@
+package service
+
+import (
+	"fmt"
+	"internship/internal/config"
+	"internship/internal/domain/entity"
+)
+
+// MergePolicy определяет условия merge PR: число назначенных ревьюверов, число их одобрений
+// и отсутствие неснятых запросов изменений. Число одобрений может переопределяться для команды автора,
+// а минимальное число ревьюверов задается в настройках команды
+type MergePolicy struct {
+	requiredApprovals int
+	byTeam            map[string]int
+}
+
+// NewMergePolicy создает политику merge по конфигурации
+func NewMergePolicy(cfg config.MergeConfig) (*MergePolicy, error) {
+	if cfg.RequiredApprovals < 0 {
+		return nil, fmt.Errorf("invalid required approvals %d", cfg.RequiredApprovals)
+	}
+
+	byTeam := make(map[string]int, len(cfg.Teams))
+	for _, team := range cfg.Teams {
+		if team.RequiredApprovals < 0 {
+			return nil, fmt.Errorf("team %s: invalid required approvals %d", team.TeamName, team.RequiredApprovals)
+		}
+		byTeam[team.TeamName] = team.RequiredApprovals
+	}
+
+	return &MergePolicy{
+		requiredApprovals: cfg.RequiredApprovals,
+		byTeam:            byTeam,
+	}, nil
+}
+
+// RequiredApprovals возвращает число одобрений, необходимое для merge PR автора из команды
+func (p *MergePolicy) RequiredApprovals(teamName string) int {
+	if required, ok := p.byTeam[teamName]; ok {
+		return required
+	}
+	return p.requiredApprovals
+}
+
+// Check возвращает невыполненные условия merge PR автора из команды с настройками ревьюверов settings.
+// Учитывается последнее решение APPROVED или CHANGES_REQUESTED каждого назначенного сейчас ревьювера:
+// комментарии не меняют решение, а решения снятых с PR ревьюверов не действуют
+func (p *MergePolicy) Check(teamName string, settings entity.ReviewerSettings, pr *entity.PullRequest) []entity.UnmetMergeCondition {
+	latest := make(map[string]entity.ReviewDecisionType, len(pr.AssignedReviewers))
+	for _, review := range pr.Reviews {
+		if review.Decision != entity.ReviewCommented {
+			latest[review.ReviewerID] = review.Decision
+		}
+	}
+
+	var approved int
+	pending := []string{}
+	changesRequested := []string{}
+	for _, reviewerID := range pr.AssignedReviewers {
+		switch latest[reviewerID] {
+		case entity.ReviewApproved:
+			approved++
+		case entity.ReviewChangesRequested:
+			changesRequested = append(changesRequested, reviewerID)
+		default:
+			pending = append(pending, reviewerID)
+		}
+	}
+
+	var unmet []entity.UnmetMergeCondition
+	if assigned := len(pr.AssignedReviewers); assigned < settings.MinReviewers {
+		unmet = append(unmet, entity.UnmetMergeCondition{
+			Condition: entity.MergeConditionMinReviewers,
+			Message:   fmt.Sprintf("%d reviewers required, %d assigned", settings.MinReviewers, assigned),
+		})
+	}
+	if required := p.RequiredApprovals(teamName); approved < required {
+		unmet = append(unmet, entity.UnmetMergeCondition{
+			Condition: entity.MergeConditionApprovals,
+			Message:   fmt.Sprintf("%d approvals required, %d given", required, approved),
+			Reviewers: append(pending, changesRequested...),
+		})
+	}
+	if len(changesRequested) > 0 {
+		unmet = append(unmet, entity.UnmetMergeCondition{
+			Condition: entity.MergeConditionNoChangesRequested,
+			Message:   fmt.Sprintf("%d reviewers requested changes", len(changesRequested)),
+			Reviewers: changesRequested,
+		})
+	}
+
+	return unmet
+}
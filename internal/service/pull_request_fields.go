@@ -0,0 +1,170 @@
+package service
+
+import (
+	"encoding/json"
+	"fmt"
+	"internship/internal/domain/entity"
+	"maps"
+	"slices"
+	"strings"
+	"unicode/utf8"
+)
+
+// Ограничения редактируемых полей PR
+const (
+	maxNameLength          = 255
+	maxDescriptionLength   = 10000
+	maxLabels              = 20
+	maxLabelLength         = 50
+	maxMetadataEntries     = 20
+	maxMetadataKeyLength   = 64
+	maxMetadataValueLength = 1024
+)
+
+// validateName проверяет название PR
+func validateName(name string) error {
+	if strings.TrimSpace(name) == "" {
+		return fmt.Errorf("%w: pull_request_name must not be empty", entity.ErrInvalidInput)
+	}
+	if utf8.RuneCountInString(name) > maxNameLength {
+		return fmt.Errorf("%w: pull_request_name must be at most %d characters", entity.ErrInvalidInput, maxNameLength)
+	}
+	return nil
+}
+
+// validateDescription проверяет описание PR
+func validateDescription(description string) error {
+	if utf8.RuneCountInString(description) > maxDescriptionLength {
+		return fmt.Errorf("%w: description must be at most %d characters", entity.ErrInvalidInput, maxDescriptionLength)
+	}
+	return nil
+}
+
+// validateLabels проверяет, что метки непустые, не повторяются и не превышают ограничений
+func validateLabels(labels []string) error {
+	if len(labels) > maxLabels {
+		return fmt.Errorf("%w: at most %d labels allowed", entity.ErrInvalidInput, maxLabels)
+	}
+
+	seen := make(map[string]bool, len(labels))
+	for _, label := range labels {
+		if strings.TrimSpace(label) == "" {
+			return fmt.Errorf("%w: labels must not be empty", entity.ErrInvalidInput)
+		}
+		if utf8.RuneCountInString(label) > maxLabelLength {
+			return fmt.Errorf("%w: label %q must be at most %d characters", entity.ErrInvalidInput, label, maxLabelLength)
+		}
+		if seen[label] {
+			return fmt.Errorf("%w: duplicate label %q", entity.ErrInvalidInput, label)
+		}
+		seen[label] = true
+	}
+	return nil
+}
+
+// validateMetadata проверяет ключи и значения метаданных автора
+func validateMetadata(metadata map[string]string) error {
+	if len(metadata) > maxMetadataEntries {
+		return fmt.Errorf("%w: at most %d metadata entries allowed", entity.ErrInvalidInput, maxMetadataEntries)
+	}
+
+	for key, value := range metadata {
+		if strings.TrimSpace(key) == "" {
+			return fmt.Errorf("%w: metadata keys must not be empty", entity.ErrInvalidInput)
+		}
+		if utf8.RuneCountInString(key) > maxMetadataKeyLength {
+			return fmt.Errorf("%w: metadata key %q must be at most %d characters", entity.ErrInvalidInput, key, maxMetadataKeyLength)
+		}
+		if utf8.RuneCountInString(value) > maxMetadataValueLength {
+			return fmt.Errorf("%w: metadata value of %q must be at most %d characters", entity.ErrInvalidInput, key, maxMetadataValueLength)
+		}
+	}
+	return nil
+}
+
+// validatePullRequestFields проверяет редактируемые поля нового PR
+func validatePullRequestFields(pr *entity.PullRequest) error {
+	if err := validateName(pr.PullRequestName); err != nil {
+		return err
+	}
+	if err := validateDescription(pr.Description); err != nil {
+		return err
+	}
+	if err := validateLabels(pr.Labels); err != nil {
+		return err
+	}
+	if err := validateChangedFiles(pr.ChangedFiles); err != nil {
+		return err
+	}
+	return validateMetadata(pr.Metadata)
+}
+
+// validateUpdate проверяет поля, переданные в правке PR
+func validateUpdate(update *entity.PullRequestUpdate) error {
+	if update.PullRequestName != nil {
+		if err := validateName(*update.PullRequestName); err != nil {
+			return err
+		}
+	}
+	if update.Description != nil {
+		if err := validateDescription(*update.Description); err != nil {
+			return err
+		}
+	}
+	if update.Labels != nil {
+		if err := validateLabels(update.Labels); err != nil {
+			return err
+		}
+	}
+	if update.Metadata != nil {
+		if err := validateMetadata(update.Metadata); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// applyUpdate применяет правку к PR и возвращает изменения полей с прежними значениями.
+// Поля, значение которых не изменилось, в изменения не попадают
+func applyUpdate(pr *entity.PullRequest, update *entity.PullRequestUpdate) ([]entity.PullRequestChange, error) {
+	changes := []entity.PullRequestChange{}
+	record := func(field string, oldValue, newValue any) error {
+		oldJSON, err := json.Marshal(oldValue)
+		if err != nil {
+			return fmt.Errorf("encode old %s: %w", field, err)
+		}
+		newJSON, err := json.Marshal(newValue)
+		if err != nil {
+			return fmt.Errorf("encode new %s: %w", field, err)
+		}
+		changes = append(changes, entity.PullRequestChange{Field: field, OldValue: oldJSON, NewValue: newJSON})
+		return nil
+	}
+
+	if update.PullRequestName != nil && *update.PullRequestName != pr.PullRequestName {
+		if err := record(entity.PRFieldName, pr.PullRequestName, *update.PullRequestName); err != nil {
+			return nil, err
+		}
+		pr.PullRequestName = *update.PullRequestName
+	}
+	if update.Description != nil && *update.Description != pr.Description {
+		if err := record(entity.PRFieldDescription, pr.Description, *update.Description); err != nil {
+			return nil, err
+		}
+		pr.Description = *update.Description
+	}
+	if update.Labels != nil && !slices.Equal(update.Labels, pr.Labels) {
+		if err := record(entity.PRFieldLabels, pr.Labels, update.Labels); err != nil {
+			return nil, err
+		}
+		pr.Labels = update.Labels
+	}
+	if update.Metadata != nil && !maps.Equal(update.Metadata, pr.Metadata) {
+		if err := record(entity.PRFieldMetadata, pr.Metadata, update.Metadata); err != nil {
+			return nil, err
+		}
+		pr.Metadata = update.Metadata
+	}
+
+	return changes, nil
+}
@@ -0,0 +1,230 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"internship/internal/domain/entity"
+	"slices"
+
+	"go.uber.org/zap"
+)
+
+// AddReviewer назначает на PR выбранного пользователя по тем же правилам, что и автоматическое назначение:
+// пользователь активен, не является автором, не достиг лимита открытых ревью, а у PR меньше max_reviewers ревьюверов.
+// Слитый и закрытый PR не изменяются
+func (s *PullRequestService) AddReviewer(ctx context.Context, prID, userID string, expectedVersion *int) (*entity.PullRequest, error) {
+	var pr *entity.PullRequest
+	err := s.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		return s.auditPullRequest(ctx, entity.AuditActionPRAssign, prID, func(locked *entity.PullRequest) (*entity.PullRequest, error) {
+			var err error
+			pr, err = s.addReviewer(ctx, locked, userID, expectedVersion)
+			return pr, err
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return pr, nil
+}
+
+func (s *PullRequestService) addReviewer(ctx context.Context, pr *entity.PullRequest, userID string, expectedVersion *int) (*entity.PullRequest, error) {
+	if err := s.checkModifiableVersion(pr, expectedVersion); err != nil {
+		return nil, err
+	}
+
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		s.log.Error("get user", zap.Error(err))
+		return nil, fmt.Errorf("get user: %w", err)
+	}
+
+	if slices.Contains(pr.AssignedReviewers, userID) {
+		s.log.Error("already assigned", zap.String("pr_id", pr.PullRequestID), zap.String("user_id", userID))
+		return nil, entity.ErrAlreadyAssigned
+	}
+	settings, err := s.authorSettings(ctx, pr)
+	if err != nil {
+		return nil, err
+	}
+	if len(pr.AssignedReviewers) >= settings.MaxReviewers {
+		s.log.Error("reviewer limit reached", zap.String("pr_id", pr.PullRequestID), zap.Int("reviewers", len(pr.AssignedReviewers)))
+		return nil, fmt.Errorf("%w: at most %d reviewers allowed", entity.ErrReviewerLimit, settings.MaxReviewers)
+	}
+	if err := s.checkCandidate(ctx, pr, user); err != nil {
+		s.log.Error("invalid reviewer candidate", zap.String("pr_id", pr.PullRequestID), zap.String("user_id", userID), zap.Error(err))
+		return nil, err
+	}
+
+	if err := s.reviewerRepo.AssignReviewer(ctx, pr.PullRequestID, userID); err != nil {
+		s.log.Error("assign reviewer", zap.Error(err))
+		return nil, fmt.Errorf("assign reviewer: %w", err)
+	}
+	pr.AssignedReviewers = append(pr.AssignedReviewers, userID)
+	setReviewerTeam(pr, *user)
+
+	event := newAssignmentEvent(ctx, pr.PullRequestID, entity.AssignmentEventAssign, entity.AssignmentReasonManualAssign, userID)
+	if err := s.recordEvents(ctx, []entity.ReviewerAssignmentEvent{event}); err != nil {
+		return nil, err
+	}
+
+	if err := s.updateReviewerCount(ctx, pr, settings); err != nil {
+		return nil, err
+	}
+
+	s.log.Info("added reviewer", zap.String("pr_id", pr.PullRequestID), zap.String("user_id", userID))
+	return pr, nil
+}
+
+// RemoveReviewer снимает ревьювера с PR. Снятый вручную пользователь больше не назначается на PR автоматически,
+// а освободившееся место доназначается фоновой задачей. Слитый и закрытый PR не изменяются
+func (s *PullRequestService) RemoveReviewer(ctx context.Context, prID, userID string, expectedVersion *int) (*entity.PullRequest, error) {
+	var pr *entity.PullRequest
+	err := s.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		return s.auditPullRequest(ctx, entity.AuditActionPRUnassign, prID, func(locked *entity.PullRequest) (*entity.PullRequest, error) {
+			var err error
+			pr, err = s.removeReviewer(ctx, locked, userID, expectedVersion)
+			return pr, err
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return pr, nil
+}
+
+func (s *PullRequestService) removeReviewer(ctx context.Context, pr *entity.PullRequest, userID string, expectedVersion *int) (*entity.PullRequest, error) {
+	if err := s.checkModifiableVersion(pr, expectedVersion); err != nil {
+		return nil, err
+	}
+
+	if !slices.Contains(pr.AssignedReviewers, userID) {
+		s.log.Error("not assigned", zap.String("pr_id", pr.PullRequestID), zap.String("user_id", userID))
+		return nil, entity.ErrNotAssigned
+	}
+
+	settings, err := s.authorSettings(ctx, pr)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.reviewerRepo.RemoveReviewer(ctx, pr.PullRequestID, userID); err != nil {
+		s.log.Error("remove reviewer", zap.Error(err))
+		return nil, fmt.Errorf("remove reviewer: %w", err)
+	}
+	pr.AssignedReviewers = slices.DeleteFunc(pr.AssignedReviewers, func(reviewerID string) bool {
+		return reviewerID == userID
+	})
+
+	event := newAssignmentEvent(ctx, pr.PullRequestID, entity.AssignmentEventUnassign, entity.AssignmentReasonManualRemove, userID)
+	if err := s.recordEvents(ctx, []entity.ReviewerAssignmentEvent{event}); err != nil {
+		return nil, err
+	}
+
+	if err := s.updateReviewerCount(ctx, pr, settings); err != nil {
+		return nil, err
+	}
+
+	s.log.Info("removed reviewer", zap.String("pr_id", pr.PullRequestID), zap.String("user_id", userID))
+	return pr, nil
+}
+
+// checkModifiableVersion проверяет, что состав ревьюверов заблокированного PR можно менять
+// и что версия PR совпадает с ожидаемой клиентом
+func (s *PullRequestService) checkModifiableVersion(pr *entity.PullRequest, expectedVersion *int) error {
+	if err := checkModifiable(pr); err != nil {
+		s.log.Error("pr is not modifiable", zap.String("pr_id", pr.PullRequestID), zap.String("status", string(pr.Status)))
+		return err
+	}
+
+	if err := checkVersion(pr, expectedVersion); err != nil {
+		s.log.Error("version conflict", zap.String("pr_id", pr.PullRequestID), zap.Int("version", pr.Version))
+		return err
+	}
+
+	return nil
+}
+
+// checkCandidate проверяет, что пользователя можно назначить ревьювером PR:
+// он не автор, активен и не достиг лимита открытых ревью
+func (s *PullRequestService) checkCandidate(ctx context.Context, pr *entity.PullRequest, user *entity.User) error {
+	if user.UserID == pr.AuthorID {
+		return entity.ErrAuthorReviewer
+	}
+	if !user.IsActive {
+		return entity.ErrUserInactive
+	}
+
+	available, _, err := s.assigner.filterByCapacity(ctx, user.TeamName, []entity.User{*user})
+	if err != nil {
+		return fmt.Errorf("filter by capacity: %w", err)
+	}
+	if len(available) == 0 {
+		return entity.ErrReviewerAtCapacity
+	}
+
+	return nil
+}
+
+// checkReplacement проверяет, что выбранного пользователя можно назначить вместо ревьювера oldReviewer:
+// он состоит в той же команде, еще не назначен на PR и проходит проверки checkCandidate.
+// Резервные команды при явном выборе не используются. Явный выбор допускает и пользователя, ранее снятого с PR вручную
+func (s *PullRequestService) checkReplacement(ctx context.Context, pr *entity.PullRequest, oldReviewer *entity.User, newUserID string) (*entity.User, error) {
+	newReviewer, err := s.userRepo.GetByID(ctx, newUserID)
+	if err != nil {
+		s.log.Error("get new reviewer", zap.Error(err))
+		return nil, fmt.Errorf("get new reviewer: %w", err)
+	}
+
+	if slices.Contains(pr.AssignedReviewers, newUserID) {
+		s.log.Error("already assigned", zap.String("pr_id", pr.PullRequestID), zap.String("new_user_id", newUserID))
+		return nil, entity.ErrAlreadyAssigned
+	}
+
+	if newReviewer.TeamName != oldReviewer.TeamName {
+		s.log.Error("team mismatch", zap.String("pr_id", pr.PullRequestID), zap.String("new_user_id", newUserID),
+			zap.String("team_name", newReviewer.TeamName), zap.String("expected_team", oldReviewer.TeamName))
+		return nil, fmt.Errorf("%w: expected team %s", entity.ErrTeamMismatch, oldReviewer.TeamName)
+	}
+	if err := s.checkCandidate(ctx, pr, newReviewer); err != nil {
+		s.log.Error("invalid reviewer candidate", zap.String("pr_id", pr.PullRequestID), zap.String("new_user_id", newUserID), zap.Error(err))
+		return nil, err
+	}
+
+	return newReviewer, nil
+}
+
+// updateReviewerCount сохраняет PR после изменения числа ревьюверов
+func (s *PullRequestService) updateReviewerCount(ctx context.Context, pr *entity.PullRequest, settings entity.ReviewerSettings) error {
+	pr.NeedMoreReviewers = needMoreReviewers(len(pr.AssignedReviewers), settings)
+	pr.ShortageReason = ""
+	if err := s.prRepo.Update(ctx, pr); err != nil {
+		s.log.Error("update pr", zap.Error(err))
+		return fmt.Errorf("update pr: %w", err)
+	}
+	return nil
+}
+
+// manuallyRemoved возвращает пользователей, снятых с PR вручную и с тех пор не назначенных снова.
+// Автоматическое назначение их пропускает
+func (s *PullRequestService) manuallyRemoved(ctx context.Context, prID string) (map[string]bool, error) {
+	events, err := s.eventRepo.GetEvents(ctx, prID)
+	if err != nil {
+		return nil, fmt.Errorf("get assignment events: %w", err)
+	}
+
+	removed := make(map[string]bool)
+	for _, event := range events {
+		switch event.Event {
+		case entity.AssignmentEventUnassign:
+			if event.Reason == entity.AssignmentReasonManualRemove {
+				removed[event.UserID] = true
+			}
+		case entity.AssignmentEventAssign, entity.AssignmentEventReplace:
+			delete(removed, event.UserID)
+		}
+	}
+
+	return removed, nil
+}
@@ -2,9 +2,9 @@ package service
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"internship/internal/domain/entity"
-	"math/rand"
 	"time"
 
 	"go.uber.org/zap"
@@ -14,25 +14,82 @@ type PullRequestService struct {
 	prRepo       PullRequestRepositoryInterface
 	userRepo     UserRepositoryInterface
 	reviewerRepo ReviewerRepositoryInterface
+	reviewRepo   ReviewDecisionRepositoryInterface
+	changeRepo   PullRequestChangeRepositoryInterface
+	eventRepo    AssignmentEventRepositoryInterface
+	audit        *auditLog
+	assigner     *reviewerAssigner
+	mergePolicy  *MergePolicy
+	txManager    TransactionManager
 	log          *zap.Logger
 }
 
 func NewPullRequestService(
 	prRepo PullRequestRepositoryInterface,
+	teamRepo TeamRepositoryInterface,
 	userRepo UserRepositoryInterface,
 	reviewerRepo ReviewerRepositoryInterface,
+	reviewRepo ReviewDecisionRepositoryInterface,
+	changeRepo PullRequestChangeRepositoryInterface,
+	eventRepo AssignmentEventRepositoryInterface,
+	auditRepo AuditRepositoryInterface,
+	selector ReviewerSelector,
+	mergePolicy *MergePolicy,
+	txManager TransactionManager,
 	log *zap.Logger,
 ) *PullRequestService {
 	return &PullRequestService{
 		prRepo:       prRepo,
 		userRepo:     userRepo,
 		reviewerRepo: reviewerRepo,
+		reviewRepo:   reviewRepo,
+		changeRepo:   changeRepo,
+		eventRepo:    eventRepo,
+		audit:        newAuditLog(auditRepo, log),
+		assigner:     newReviewerAssigner(teamRepo, userRepo, reviewerRepo, selector, log),
+		mergePolicy:  mergePolicy,
+		txManager:    txManager,
 		log:          log,
 	}
 }
 
-// CreatePullRequest создает PR и автоматически назначает до 2 ревьюверов
+// CreatePullRequest создает PR и автоматически назначает ревьюверов до max_reviewers команды автора,
+// в первую очередь владельцев измененных файлов и пользователей с требуемыми тегами экспертизы.
+// PR и назначения ревьюверов сохраняются в одной транзакции
 func (s *PullRequestService) CreatePullRequest(ctx context.Context, pr *entity.PullRequest) (*entity.PullRequest, error) {
+	if err := validatePullRequestFields(pr); err != nil {
+		s.log.Error("invalid pr fields", zap.Error(err))
+		return nil, err
+	}
+	requiredTags, err := normalizeTags("required_tags", pr.RequiredTags)
+	if err != nil {
+		s.log.Error("invalid required tags", zap.Error(err))
+		return nil, err
+	}
+	pr.RequiredTags = requiredTags
+
+	var created *entity.PullRequest
+	err = s.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		created, err = s.createPullRequest(ctx, pr)
+		if err != nil {
+			return err
+		}
+		return s.audit.record(ctx, auditChange{
+			action:     entity.AuditActionPRCreate,
+			entityType: entity.AuditEntityPullRequest,
+			entityID:   created.PullRequestID,
+			after:      created,
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return created, nil
+}
+
+func (s *PullRequestService) createPullRequest(ctx context.Context, pr *entity.PullRequest) (*entity.PullRequest, error) {
 
 	exists, err := s.prRepo.Exists(ctx, pr.PullRequestID)
 	if err != nil {
@@ -51,52 +108,211 @@ func (s *PullRequestService) CreatePullRequest(ctx context.Context, pr *entity.P
 		return nil, fmt.Errorf("get author: %w", err)
 	}
 
-	teamMembers, err := s.userRepo.GetByTeamName(ctx, author.TeamName)
-	if err != nil {
-		s.log.Error("get team members", zap.Error(err))
-		return nil, fmt.Errorf("get team members: %w", err)
+	now := time.Now()
+	pr.CreatedAt = &now
+	pr.AssignedReviewers = []string{}
+	pr.Reviews = []entity.ReviewDecision{}
+	if pr.Labels == nil {
+		pr.Labels = []string{}
+	}
+	if pr.Metadata == nil {
+		pr.Metadata = map[string]string{}
+	}
+	if pr.ChangedFiles == nil {
+		pr.ChangedFiles = []string{}
+	}
+	if pr.RequiredTags == nil {
+		pr.RequiredTags = []string{}
 	}
 
-	candidates := make([]entity.User, 0)
-	for _, member := range teamMembers {
-		if member.UserID != pr.AuthorID && member.IsActive {
-			candidates = append(candidates, member)
+	// Черновик создается без ревьюверов: они назначаются, когда PR отмечен готовым
+	if pr.Status == entity.PRStatusDraft {
+		if err := s.prRepo.Create(ctx, pr); err != nil {
+			s.log.Error("create pr", zap.Error(err))
+			return nil, fmt.Errorf("create pr: %w", err)
 		}
+		return pr, nil
 	}
 
-	reviewers := s.selectRandomReviewers(candidates, 2)
+	reviewers, err := s.chooseReviewers(ctx, pr, author.TeamName, nil)
+	if err != nil {
+		return nil, err
+	}
 
 	pr.Status = entity.PRStatusOpen
-	now := time.Now()
-	pr.CreatedAt = &now
 
 	if err := s.prRepo.Create(ctx, pr); err != nil {
 		s.log.Error("create pr", zap.Error(err))
 		return nil, fmt.Errorf("create pr: %w", err)
 	}
 
+	if err := s.assignReviewers(ctx, pr, reviewers, entity.AssignmentReasonAutoCreate); err != nil {
+		return nil, err
+	}
+
+	return pr, nil
+}
+
+// auditPullRequest блокирует PR до конца транзакции, выполняет над ним изменение change и записывает в журнал аудита
+// состояние PR до и после него. Параллельные изменения того же PR ждут завершения транзакции.
+// Вызывается внутри транзакции изменения; change получает уже заблокированный PR и не читает его повторно
+func (s *PullRequestService) auditPullRequest(ctx context.Context, action entity.AuditAction, prID string, change func(pr *entity.PullRequest) (*entity.PullRequest, error)) error {
+	pr, err := s.prRepo.GetByIDForUpdate(ctx, prID)
+	if err != nil {
+		s.log.Error("get pr", zap.Error(err))
+		return fmt.Errorf("get pr: %w", err)
+	}
+	before, err := s.audit.snapshot(pr)
+	if err != nil {
+		return err
+	}
+
+	after, err := change(pr)
+	if err != nil {
+		return err
+	}
+
+	return s.audit.record(ctx, auditChange{
+		action:     action,
+		entityType: entity.AuditEntityPullRequest,
+		entityID:   prID,
+		before:     before,
+		after:      after,
+	})
+}
+
+// chooseReviewers выбирает недостающих до max_reviewers ревьюверов, кроме skipped: сначала владельцев
+// измененных файлов, затем из команды автора и ее резервных команд, и выставляет PR флаг нехватки ревьюверов с причиной
+func (s *PullRequestService) chooseReviewers(ctx context.Context, pr *entity.PullRequest, teamName string, skipped map[string]bool) ([]entity.User, error) {
+	settings, err := s.assigner.reviewerSettings(ctx, teamName)
+	if err != nil {
+		s.log.Error("get reviewer settings", zap.Error(err))
+		return nil, fmt.Errorf("get reviewer settings: %w", err)
+	}
+
+	pools, err := s.assigner.reviewerPools(ctx, teamName, teamName)
+	if err != nil {
+		s.log.Error("get reviewer pools", zap.Error(err))
+		return nil, fmt.Errorf("get reviewer pools: %w", err)
+	}
+
+	excluded := map[string]bool{pr.AuthorID: true}
+	for _, reviewerID := range pr.AssignedReviewers {
+		excluded[reviewerID] = true
+	}
+	for userID := range skipped {
+		excluded[userID] = true
+	}
+
+	reviewers, matched, atCapacity, err := s.assigner.pickForPullRequest(ctx, pr, teamName, pools, excluded, settings.MaxReviewers-len(pr.AssignedReviewers))
+	if err != nil {
+		s.log.Error("pick reviewers", zap.Error(err))
+		return nil, fmt.Errorf("pick reviewers: %w", err)
+	}
+	setReviewerRules(pr, matched)
+
+	// Объясняем, почему назначено меньше max_reviewers ревьюверов
+	pr.NeedMoreReviewers = needMoreReviewers(len(pr.AssignedReviewers)+len(reviewers), settings)
+	pr.ShortageReason = ""
+	if pr.NeedMoreReviewers {
+		pr.ShortageReason = entity.ShortageNoActiveCandidates
+		if atCapacity > 0 {
+			pr.ShortageReason = entity.ShortageCapacityReached
+		}
+	}
+
+	return reviewers, nil
+}
+
+// assignReviewers назначает выбранных ревьюверов на PR и записывает назначения в историю с причиной
+func (s *PullRequestService) assignReviewers(ctx context.Context, pr *entity.PullRequest, reviewers []entity.User, reason entity.AssignmentReason) error {
+	events := make([]entity.ReviewerAssignmentEvent, 0, len(reviewers))
 	for _, reviewer := range reviewers {
 		if err := s.reviewerRepo.AssignReviewer(ctx, pr.PullRequestID, reviewer.UserID); err != nil {
 			s.log.Error("assign reviewer", zap.Error(err))
-			return nil, fmt.Errorf("assign reviewer: %w", err)
+			return fmt.Errorf("assign reviewer: %w", err)
 		}
 		pr.AssignedReviewers = append(pr.AssignedReviewers, reviewer.UserID)
+		setReviewerTeam(pr, reviewer)
+		events = append(events, newAssignmentEvent(ctx, pr.PullRequestID, entity.AssignmentEventAssign, reason, reviewer.UserID))
 	}
+	return s.recordEvents(ctx, events)
+}
 
-	return pr, nil
+// setReviewerTeam отмечает в ответе, из какой команды назначен ревьювер
+func setReviewerTeam(pr *entity.PullRequest, reviewer entity.User) {
+	if pr.ReviewerTeams == nil {
+		pr.ReviewerTeams = make(map[string]string)
+	}
+	pr.ReviewerTeams[reviewer.UserID] = reviewer.TeamName
 }
 
-// MergePullRequest помечает PR как MERGED (идемпотентная операция)
-func (s *PullRequestService) MergePullRequest(ctx context.Context, prID string) (*entity.PullRequest, error) {
-	pr, err := s.prRepo.GetByID(ctx, prID)
+// recordEvents дописывает события в историю назначений ревьюверов
+func (s *PullRequestService) recordEvents(ctx context.Context, events []entity.ReviewerAssignmentEvent) error {
+	if len(events) == 0 {
+		return nil
+	}
+	if err := s.eventRepo.AddEvents(ctx, events); err != nil {
+		s.log.Error("add assignment events", zap.Error(err))
+		return fmt.Errorf("add assignment events: %w", err)
+	}
+	return nil
+}
+
+// MergePullRequest помечает PR как MERGED (идемпотентная операция).
+// Если передана expectedVersion и она не совпадает с текущей версией открытого PR, возвращается ErrVersionConflict.
+// Если PR не удовлетворяет политике merge, возвращается MergeBlockedError со списком невыполненных условий
+func (s *PullRequestService) MergePullRequest(ctx context.Context, prID string, expectedVersion *int) (*entity.PullRequest, error) {
+	var merged *entity.PullRequest
+	err := s.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		return s.auditPullRequest(ctx, entity.AuditActionPRMerge, prID, func(locked *entity.PullRequest) (*entity.PullRequest, error) {
+			var err error
+			merged, err = s.mergePullRequest(ctx, locked, expectedVersion)
+			return merged, err
+		})
+	})
 	if err != nil {
-		s.log.Error("get pr", zap.Error(err))
-		return nil, fmt.Errorf("get pr: %w", err)
+		return nil, err
 	}
 
-	if pr.Status == entity.PRStatusMerged {
+	return merged, nil
+}
+
+func (s *PullRequestService) mergePullRequest(ctx context.Context, pr *entity.PullRequest, expectedVersion *int) (*entity.PullRequest, error) {
+	switch pr.Status {
+	case entity.PRStatusMerged:
 		return pr, nil
+	case entity.PRStatusClosed:
+		s.log.Error("pr closed", zap.String("pr_id", pr.PullRequestID))
+		return nil, entity.ErrPRClosed
+	case entity.PRStatusDraft:
+		s.log.Error("pr is draft", zap.String("pr_id", pr.PullRequestID))
+		return nil, entity.ErrPRDraft
+	}
+
+	if err := checkVersion(pr, expectedVersion); err != nil {
+		s.log.Error("version conflict", zap.String("pr_id", pr.PullRequestID), zap.Int("version", pr.Version))
+		return nil, err
+	}
+
+	// Политика merge определяется командой автора PR
+	author, err := s.userRepo.GetByID(ctx, pr.AuthorID)
+	if err != nil {
+		s.log.Error("get author", zap.Error(err))
+		return nil, fmt.Errorf("get author: %w", err)
 	}
+
+	settings, err := s.assigner.reviewerSettings(ctx, author.TeamName)
+	if err != nil {
+		s.log.Error("get reviewer settings", zap.Error(err))
+		return nil, fmt.Errorf("get reviewer settings: %w", err)
+	}
+
+	if unmet := s.mergePolicy.Check(author.TeamName, settings, pr); len(unmet) > 0 {
+		s.log.Info("merge blocked", zap.String("pr_id", pr.PullRequestID), zap.Any("unmet", unmet))
+		return nil, &entity.MergeBlockedError{Unmet: unmet}
+	}
+
 	pr.Status = entity.PRStatusMerged
 	mergedAt := time.Now()
 	pr.MergedAt = &mergedAt
@@ -109,21 +325,42 @@ func (s *PullRequestService) MergePullRequest(ctx context.Context, prID string)
 	return pr, nil
 }
 
-// ReassignReviewer переназначает ревьювера на другого из его команды
-func (s *PullRequestService) ReassignReviewer(ctx context.Context, prID, oldUserID string) (*entity.PullRequest, string, error) {
-	pr, err := s.prRepo.GetByID(ctx, prID)
+// ReassignReviewer переназначает ревьювера на другого из его команды в одной транзакции.
+// Если передан newUserID, замена назначается на него после проверки правил назначения,
+// иначе выбирается настроенной стратегией.
+// Если передана expectedVersion и она не совпадает с текущей версией PR, возвращается ErrVersionConflict
+func (s *PullRequestService) ReassignReviewer(ctx context.Context, prID, oldUserID, newUserID string, expectedVersion *int) (*entity.PullRequest, string, error) {
+	var (
+		pr            *entity.PullRequest
+		newReviewerID string
+	)
+	err := s.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		return s.auditPullRequest(ctx, entity.AuditActionPRReassign, prID, func(locked *entity.PullRequest) (*entity.PullRequest, error) {
+			var err error
+			pr, newReviewerID, err = s.reassignReviewer(ctx, locked, oldUserID, newUserID, expectedVersion)
+			return pr, err
+		})
+	})
 	if err != nil {
-		s.log.Error("get pr", zap.Error(err))
-		return nil, "", fmt.Errorf("get pr: %w", err)
+		return nil, "", err
 	}
 
-	// Проверяем, что PR не в статусе MERGED
-	if pr.Status == entity.PRStatusMerged {
-		s.log.Error("pr merged", zap.String("pr_id", pr.PullRequestID))
-		return nil, "", entity.ErrPRMerged
+	return pr, newReviewerID, nil
+}
+
+func (s *PullRequestService) reassignReviewer(ctx context.Context, pr *entity.PullRequest, oldUserID, newUserID string, expectedVersion *int) (*entity.PullRequest, string, error) {
+	// Проверяем, что PR не в статусе MERGED или CLOSED
+	if err := checkModifiable(pr); err != nil {
+		s.log.Error("pr is not modifiable", zap.String("pr_id", pr.PullRequestID), zap.String("status", string(pr.Status)))
+		return nil, "", err
+	}
+
+	if err := checkVersion(pr, expectedVersion); err != nil {
+		s.log.Error("version conflict", zap.String("pr_id", pr.PullRequestID), zap.Int("version", pr.Version))
+		return nil, "", err
 	}
 
-	isAssigned, err := s.reviewerRepo.IsAssigned(ctx, prID, oldUserID)
+	isAssigned, err := s.reviewerRepo.IsAssigned(ctx, pr.PullRequestID, oldUserID)
 	if err != nil {
 		s.log.Error("check is assigned", zap.Error(err))
 		return nil, "", fmt.Errorf("check is assigned: %w", err)
@@ -140,85 +377,597 @@ func (s *PullRequestService) ReassignReviewer(ctx context.Context, prID, oldUser
 		return nil, "", fmt.Errorf("get old reviewer: %w", err)
 	}
 
-	teamMembers, err := s.userRepo.GetByTeamName(ctx, oldReviewer.TeamName)
+	var newReviewer *entity.User
+	if newUserID != "" {
+		newReviewer, err = s.checkReplacement(ctx, pr, oldReviewer, newUserID)
+	} else {
+		newReviewer, err = s.selectReplacement(ctx, pr, oldReviewer)
+	}
 	if err != nil {
-		s.log.Error("get team members", zap.Error(err))
-		return nil, "", fmt.Errorf("get team members: %w", err)
+		return nil, "", err
+	}
+	newReviewerID := newReviewer.UserID
+
+	// Заменяем ревьювера
+	if err := s.reviewerRepo.ReplaceReviewer(ctx, pr.PullRequestID, oldUserID, newReviewerID); err != nil {
+		s.log.Error("replace reviewer", zap.Error(err))
+		return nil, "", fmt.Errorf("replace reviewer: %w", err)
+	}
+	event := newAssignmentEvent(ctx, pr.PullRequestID, entity.AssignmentEventReplace, entity.AssignmentReasonManualReassign, newReviewerID)
+	event.PreviousUserID = oldUserID
+	if err := s.recordEvents(ctx, []entity.ReviewerAssignmentEvent{event}); err != nil {
+		return nil, "", err
+	}
+
+	// Обновляем список ревьюверов в PR
+	for i, reviewerID := range pr.AssignedReviewers {
+		if reviewerID == oldUserID {
+			pr.AssignedReviewers[i] = newReviewerID
+			break
+		}
+	}
+	setReviewerTeam(pr, *newReviewer)
+	settings, err := s.authorSettings(ctx, pr)
+	if err != nil {
+		return nil, "", err
+	}
+	pr.NeedMoreReviewers = needMoreReviewers(len(pr.AssignedReviewers), settings)
+	if err := s.prRepo.Update(ctx, pr); err != nil {
+		s.log.Error("update pr", zap.Error(err))
+		return nil, "", fmt.Errorf("update pr: %w", err)
+	}
+
+	s.log.Info("reassigned reviewer", zap.String("pr_id", pr.PullRequestID), zap.String("old_user_id", oldUserID), zap.String("new_user_id", newReviewerID))
+	return pr, newReviewerID, nil
+}
+
+// selectReplacement выбирает замену ревьювера настроенной стратегией: сначала среди владельцев измененных файлов,
+// затем среди активных участников его команды, а если их нет — среди участников резервных команд команды автора
+func (s *PullRequestService) selectReplacement(ctx context.Context, pr *entity.PullRequest, oldReviewer *entity.User) (*entity.User, error) {
+	author, err := s.userRepo.GetByID(ctx, pr.AuthorID)
+	if err != nil {
+		s.log.Error("get author", zap.Error(err))
+		return nil, fmt.Errorf("get author: %w", err)
+	}
+
+	pools, err := s.assigner.reviewerPools(ctx, oldReviewer.TeamName, author.TeamName)
+	if err != nil {
+		s.log.Error("get reviewer pools", zap.Error(err))
+		return nil, fmt.Errorf("get reviewer pools: %w", err)
 	}
 
 	// Фильтруем кандидатов: активные участники, кроме:
 	// - старого ревьювера
 	// - автора PR
 	// - уже назначенных ревьюверов
-	assignedMap := make(map[string]bool)
+	// - снятых с PR вручную
+	excluded, err := s.manuallyRemoved(ctx, pr.PullRequestID)
+	if err != nil {
+		s.log.Error("get manually removed reviewers", zap.Error(err))
+		return nil, fmt.Errorf("get manually removed reviewers: %w", err)
+	}
+	excluded[oldReviewer.UserID] = true
+	excluded[pr.AuthorID] = true
 	for _, reviewerID := range pr.AssignedReviewers {
-		assignedMap[reviewerID] = true
+		excluded[reviewerID] = true
 	}
 
-	candidates := make([]entity.User, 0)
-	for _, member := range teamMembers {
-		if member.UserID != oldUserID &&
-			member.UserID != pr.AuthorID &&
-			!assignedMap[member.UserID] &&
-			member.IsActive {
-			candidates = append(candidates, member)
-		}
+	// Выбираем кандидата настроенной стратегией
+	selected, matched, atCapacity, err := s.assigner.pickForPullRequest(ctx, pr, author.TeamName, pools, excluded, 1)
+	if err != nil {
+		s.log.Error("select reviewer", zap.Error(err))
+		return nil, fmt.Errorf("select reviewer: %w", err)
 	}
 
 	// Проверяем наличие кандидатов
-	if len(candidates) == 0 {
-		s.log.Error("no candidates", zap.String("pr_id", pr.PullRequestID), zap.String("old_user_id", oldUserID))
-		return nil, "", entity.ErrNoCandidate
+	if len(selected) == 0 {
+		s.log.Error("no candidates", zap.String("pr_id", pr.PullRequestID), zap.String("old_user_id", oldReviewer.UserID),
+			zap.Strings("teams", pools), zap.Int("at_capacity", atCapacity))
+		if atCapacity > 0 {
+			return nil, fmt.Errorf("%w: all candidates reached max open reviews", entity.ErrNoCandidate)
+		}
+		return nil, entity.ErrNoCandidate
 	}
+	setReviewerRules(pr, matched)
+	return &selected[0], nil
+}
 
-	// Выбираем случайного кандидата
-	newReviewer := candidates[rand.Intn(len(candidates))]
+// SubmitReview сохраняет решение назначенного ревьювера по PR и возвращает PR с решениями.
+// Решения по PR в статусе MERGED или CLOSED не принимаются
+func (s *PullRequestService) SubmitReview(ctx context.Context, prID string, decision *entity.ReviewDecision) (*entity.PullRequest, error) {
+	if !decision.Decision.IsValid() {
+		s.log.Error("invalid review decision", zap.String("decision", string(decision.Decision)))
+		return nil, fmt.Errorf("%w: unknown review decision %q", entity.ErrInvalidInput, decision.Decision)
+	}
 
-	// Заменяем ревьювера
-	if err := s.reviewerRepo.ReplaceReviewer(ctx, prID, oldUserID, newReviewer.UserID); err != nil {
-		s.log.Error("replace reviewer", zap.Error(err))
-		return nil, "", fmt.Errorf("replace reviewer: %w", err)
+	var pr *entity.PullRequest
+	err := s.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		return s.auditPullRequest(ctx, entity.AuditActionPRReview, prID, func(locked *entity.PullRequest) (*entity.PullRequest, error) {
+			var err error
+			pr, err = s.submitReview(ctx, locked, decision)
+			return pr, err
+		})
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	// Обновляем список ревьюверов в PR
-	for i, reviewerID := range pr.AssignedReviewers {
-		if reviewerID == oldUserID {
-			pr.AssignedReviewers[i] = newReviewer.UserID
-			break
-		}
+	return pr, nil
+}
+
+func (s *PullRequestService) submitReview(ctx context.Context, pr *entity.PullRequest, decision *entity.ReviewDecision) (*entity.PullRequest, error) {
+	if err := checkModifiable(pr); err != nil {
+		s.log.Error("pr is not modifiable", zap.String("pr_id", pr.PullRequestID), zap.String("status", string(pr.Status)))
+		return nil, err
+	}
+
+	isAssigned, err := s.reviewerRepo.IsAssigned(ctx, pr.PullRequestID, decision.ReviewerID)
+	if err != nil {
+		s.log.Error("check is assigned", zap.Error(err))
+		return nil, fmt.Errorf("check is assigned: %w", err)
+	}
+
+	if !isAssigned {
+		s.log.Error("not assigned", zap.String("pr_id", pr.PullRequestID), zap.String("reviewer_id", decision.ReviewerID))
+		return nil, entity.ErrNotAssigned
+	}
+
+	if err := s.reviewRepo.AddDecision(ctx, pr.PullRequestID, decision); err != nil {
+		s.log.Error("add review decision", zap.Error(err))
+		return nil, fmt.Errorf("add review decision: %w", err)
+	}
+	pr.Reviews = append(pr.Reviews, *decision)
+
+	s.log.Info("review submitted", zap.String("pr_id", pr.PullRequestID), zap.String("reviewer_id", decision.ReviewerID), zap.String("decision", string(decision.Decision)))
+	return pr, nil
+}
+
+// MarkReady переводит черновик в OPEN и назначает ревьюверов из команды автора.
+// Для уже открытого PR операция идемпотентна
+func (s *PullRequestService) MarkReady(ctx context.Context, prID string, expectedVersion *int) (*entity.PullRequest, error) {
+	var pr *entity.PullRequest
+	err := s.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		return s.auditPullRequest(ctx, entity.AuditActionPRReady, prID, func(locked *entity.PullRequest) (*entity.PullRequest, error) {
+			var err error
+			pr, err = s.markReady(ctx, locked, expectedVersion)
+			return pr, err
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return pr, nil
+}
+
+func (s *PullRequestService) markReady(ctx context.Context, pr *entity.PullRequest, expectedVersion *int) (*entity.PullRequest, error) {
+	if pr.Status == entity.PRStatusOpen {
+		return pr, nil
+	}
+	if err := checkModifiable(pr); err != nil {
+		s.log.Error("pr is not modifiable", zap.String("pr_id", pr.PullRequestID), zap.String("status", string(pr.Status)))
+		return nil, err
+	}
+
+	if err := checkVersion(pr, expectedVersion); err != nil {
+		s.log.Error("version conflict", zap.String("pr_id", pr.PullRequestID), zap.Int("version", pr.Version))
+		return nil, err
+	}
+
+	if err := s.openPullRequest(ctx, pr, entity.AssignmentReasonMarkReady); err != nil {
+		return nil, err
+	}
+
+	s.log.Info("pr marked ready", zap.String("pr_id", pr.PullRequestID), zap.Strings("reviewers", pr.AssignedReviewers))
+	return pr, nil
+}
+
+// ClosePullRequest закрывает черновик или открытый PR без merge.
+// Назначения ревьюверов сохраняются, но не учитываются в нагрузке, пока PR закрыт.
+// Для уже закрытого PR операция идемпотентна
+func (s *PullRequestService) ClosePullRequest(ctx context.Context, prID string, expectedVersion *int) (*entity.PullRequest, error) {
+	var pr *entity.PullRequest
+	err := s.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		return s.auditPullRequest(ctx, entity.AuditActionPRClose, prID, func(locked *entity.PullRequest) (*entity.PullRequest, error) {
+			var err error
+			pr, err = s.closePullRequest(ctx, locked, expectedVersion)
+			return pr, err
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return pr, nil
+}
+
+func (s *PullRequestService) closePullRequest(ctx context.Context, pr *entity.PullRequest, expectedVersion *int) (*entity.PullRequest, error) {
+	if pr.Status == entity.PRStatusClosed {
+		return pr, nil
+	}
+	if err := checkModifiable(pr); err != nil {
+		s.log.Error("pr is not modifiable", zap.String("pr_id", pr.PullRequestID), zap.String("status", string(pr.Status)))
+		return nil, err
+	}
+
+	if err := checkVersion(pr, expectedVersion); err != nil {
+		s.log.Error("version conflict", zap.String("pr_id", pr.PullRequestID), zap.Int("version", pr.Version))
+		return nil, err
 	}
+
+	// Закрытый PR не доназначается фоновым воркером
+	pr.Status = entity.PRStatusClosed
+	pr.NeedMoreReviewers = false
+
 	if err := s.prRepo.Update(ctx, pr); err != nil {
 		s.log.Error("update pr", zap.Error(err))
-		return nil, "", fmt.Errorf("update pr: %w", err)
+		return nil, fmt.Errorf("update pr: %w", err)
+	}
+
+	s.log.Info("pr closed", zap.String("pr_id", pr.PullRequestID))
+	return pr, nil
+}
+
+// ReopenPullRequest переоткрывает закрытый PR: снимает ревьюверов, деактивированных за время закрытия,
+// и доназначает недостающих из команды автора. Для черновика и открытого PR операция идемпотентна
+func (s *PullRequestService) ReopenPullRequest(ctx context.Context, prID string, expectedVersion *int) (*entity.PullRequest, error) {
+	var pr *entity.PullRequest
+	err := s.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		return s.auditPullRequest(ctx, entity.AuditActionPRReopen, prID, func(locked *entity.PullRequest) (*entity.PullRequest, error) {
+			var err error
+			pr, err = s.reopenPullRequest(ctx, locked, expectedVersion)
+			return pr, err
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return pr, nil
+}
+
+func (s *PullRequestService) reopenPullRequest(ctx context.Context, pr *entity.PullRequest, expectedVersion *int) (*entity.PullRequest, error) {
+	switch pr.Status {
+	case entity.PRStatusOpen, entity.PRStatusDraft:
+		return pr, nil
+	case entity.PRStatusMerged:
+		s.log.Error("pr merged", zap.String("pr_id", pr.PullRequestID))
+		return nil, entity.ErrPRMerged
+	}
+
+	if err := checkVersion(pr, expectedVersion); err != nil {
+		s.log.Error("version conflict", zap.String("pr_id", pr.PullRequestID), zap.Int("version", pr.Version))
+		return nil, err
+	}
+
+	if err := s.removeInactiveReviewers(ctx, pr, entity.AssignmentReasonReopen); err != nil {
+		return nil, err
+	}
+
+	if err := s.openPullRequest(ctx, pr, entity.AssignmentReasonReopen); err != nil {
+		return nil, err
+	}
+
+	s.log.Info("pr reopened", zap.String("pr_id", pr.PullRequestID), zap.Strings("reviewers", pr.AssignedReviewers))
+	return pr, nil
+}
+
+// openPullRequest переводит PR в OPEN и доназначает ревьюверов до max_reviewers команды автора
+func (s *PullRequestService) openPullRequest(ctx context.Context, pr *entity.PullRequest, reason entity.AssignmentReason) error {
+	author, err := s.userRepo.GetByID(ctx, pr.AuthorID)
+	if err != nil {
+		s.log.Error("get author", zap.Error(err))
+		return fmt.Errorf("get author: %w", err)
+	}
+
+	removed, err := s.manuallyRemoved(ctx, pr.PullRequestID)
+	if err != nil {
+		s.log.Error("get manually removed reviewers", zap.Error(err))
+		return fmt.Errorf("get manually removed reviewers: %w", err)
+	}
+
+	reviewers, err := s.chooseReviewers(ctx, pr, author.TeamName, removed)
+	if err != nil {
+		return err
+	}
+
+	pr.Status = entity.PRStatusOpen
+	if err := s.prRepo.Update(ctx, pr); err != nil {
+		s.log.Error("update pr", zap.Error(err))
+		return fmt.Errorf("update pr: %w", err)
 	}
 
-	s.log.Info("reassigned reviewer", zap.String("pr_id", pr.PullRequestID), zap.String("old_user_id", oldUserID), zap.String("new_user_id", newReviewer.UserID))
-	return pr, newReviewer.UserID, nil
+	return s.assignReviewers(ctx, pr, reviewers, reason)
 }
 
-// selectRandomReviewers выбирает случайных ревьюверов из списка кандидатов
-func (s *PullRequestService) selectRandomReviewers(candidates []entity.User, maxCount int) []entity.User {
-	if len(candidates) == 0 {
-		s.log.Error("no candidates", zap.Int("max_count", maxCount))
-		return []entity.User{}
+// removeInactiveReviewers снимает с PR ревьюверов, которые больше не активны, и записывает снятия в историю с причиной
+func (s *PullRequestService) removeInactiveReviewers(ctx context.Context, pr *entity.PullRequest, reason entity.AssignmentReason) error {
+	active := make([]string, 0, len(pr.AssignedReviewers))
+	var events []entity.ReviewerAssignmentEvent
+	for _, reviewerID := range pr.AssignedReviewers {
+		reviewer, err := s.userRepo.GetByID(ctx, reviewerID)
+		if err != nil {
+			s.log.Error("get reviewer", zap.Error(err))
+			return fmt.Errorf("get reviewer: %w", err)
+		}
+
+		if reviewer.IsActive {
+			active = append(active, reviewerID)
+			continue
+		}
+
+		if err := s.reviewerRepo.RemoveReviewer(ctx, pr.PullRequestID, reviewerID); err != nil {
+			s.log.Error("remove reviewer", zap.Error(err))
+			return fmt.Errorf("remove reviewer: %w", err)
+		}
+		events = append(events, newAssignmentEvent(ctx, pr.PullRequestID, entity.AssignmentEventUnassign, reason, reviewerID))
+		s.log.Info("removed inactive reviewer", zap.String("pr_id", pr.PullRequestID), zap.String("user_id", reviewerID))
 	}
+	pr.AssignedReviewers = active
 
-	count := min(maxCount, len(candidates))
+	return s.recordEvents(ctx, events)
+}
 
-	// Перемешиваем кандидатов
-	shuffled := make([]entity.User, len(candidates))
-	copy(shuffled, candidates)
-	rand.Shuffle(len(shuffled), func(i, j int) {
-		shuffled[i], shuffled[j] = shuffled[j], shuffled[i]
+// UpdatePullRequest правит название, описание, метки и метаданные PR и сохраняет историю правок.
+// Возвращает PR и изменения с прежними значениями; правка без изменений не увеличивает версию.
+// Слитый PR не редактируется
+func (s *PullRequestService) UpdatePullRequest(ctx context.Context, prID string, update *entity.PullRequestUpdate, expectedVersion *int) (*entity.PullRequest, []entity.PullRequestChange, error) {
+	if err := validateUpdate(update); err != nil {
+		s.log.Error("invalid pr update", zap.Error(err))
+		return nil, nil, err
+	}
+
+	var (
+		pr      *entity.PullRequest
+		changes []entity.PullRequestChange
+	)
+	err := s.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		return s.auditPullRequest(ctx, entity.AuditActionPRUpdate, prID, func(locked *entity.PullRequest) (*entity.PullRequest, error) {
+			var err error
+			pr, changes, err = s.updatePullRequest(ctx, locked, update, expectedVersion)
+			return pr, err
+		})
 	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return pr, changes, nil
+}
+
+func (s *PullRequestService) updatePullRequest(ctx context.Context, pr *entity.PullRequest, update *entity.PullRequestUpdate, expectedVersion *int) (*entity.PullRequest, []entity.PullRequestChange, error) {
+	if pr.Status == entity.PRStatusMerged {
+		s.log.Error("pr merged", zap.String("pr_id", pr.PullRequestID))
+		return nil, nil, entity.ErrPRMerged
+	}
+
+	if err := checkVersion(pr, expectedVersion); err != nil {
+		s.log.Error("version conflict", zap.String("pr_id", pr.PullRequestID), zap.Int("version", pr.Version))
+		return nil, nil, err
+	}
+
+	changes, err := applyUpdate(pr, update)
+	if err != nil {
+		s.log.Error("apply pr update", zap.Error(err))
+		return nil, nil, fmt.Errorf("apply pr update: %w", err)
+	}
+	if len(changes) == 0 {
+		return pr, changes, nil
+	}
+
+	if err := s.prRepo.Update(ctx, pr); err != nil {
+		s.log.Error("update pr", zap.Error(err))
+		return nil, nil, fmt.Errorf("update pr: %w", err)
+	}
+
+	if err := s.changeRepo.AddChanges(ctx, pr.PullRequestID, changes); err != nil {
+		s.log.Error("add pr changes", zap.Error(err))
+		return nil, nil, fmt.Errorf("add pr changes: %w", err)
+	}
+
+	s.log.Info("pr updated", zap.String("pr_id", pr.PullRequestID), zap.Int("changes", len(changes)))
+	return pr, changes, nil
+}
 
-	s.log.Info("selected reviewers", zap.Int("count", count))
-	return shuffled[:count]
+// GetPullRequestChanges возвращает историю правок PR в порядке внесения
+func (s *PullRequestService) GetPullRequestChanges(ctx context.Context, prID string) ([]entity.PullRequestChange, error) {
+	exists, err := s.prRepo.Exists(ctx, prID)
+	if err != nil {
+		s.log.Error("check pr exists", zap.Error(err))
+		return nil, fmt.Errorf("check pr exists: %w", err)
+	}
+	if !exists {
+		return nil, entity.ErrPRNotFound
+	}
+
+	changes, err := s.changeRepo.GetChanges(ctx, prID)
+	if err != nil {
+		s.log.Error("get pr changes", zap.Error(err))
+		return nil, fmt.Errorf("get pr changes: %w", err)
+	}
+
+	return changes, nil
+}
+
+// GetReviewerHistory возвращает историю назначений ревьюверов PR в порядке записи
+func (s *PullRequestService) GetReviewerHistory(ctx context.Context, prID string) ([]entity.ReviewerAssignmentEvent, error) {
+	exists, err := s.prRepo.Exists(ctx, prID)
+	if err != nil {
+		s.log.Error("check pr exists", zap.Error(err))
+		return nil, fmt.Errorf("check pr exists: %w", err)
+	}
+	if !exists {
+		return nil, entity.ErrPRNotFound
+	}
+
+	events, err := s.eventRepo.GetEvents(ctx, prID)
+	if err != nil {
+		s.log.Error("get assignment events", zap.Error(err))
+		return nil, fmt.Errorf("get assignment events: %w", err)
+	}
+
+	return events, nil
+}
+
+// GetPullRequestsNeedingReviewers возвращает открытые PR, которым не хватает ревьюверов
+func (s *PullRequestService) GetPullRequestsNeedingReviewers(ctx context.Context) ([]entity.PullRequest, error) {
+	prs, err := s.prRepo.GetOpenNeedingReviewers(ctx)
+	if err != nil {
+		s.log.Error("get prs needing reviewers", zap.Error(err))
+		return nil, fmt.Errorf("get prs needing reviewers: %w", err)
+	}
+
+	if prs == nil {
+		prs = []entity.PullRequest{}
+	}
+	return prs, nil
+}
+
+// BackfillReviewers доназначает ревьюверов на открытые PR, которым их не хватает.
+// Ошибка на одном PR не прерывает обработку остальных. Возвращает число сделанных назначений
+func (s *PullRequestService) BackfillReviewers(ctx context.Context) (int, error) {
+	prs, err := s.prRepo.GetOpenNeedingReviewers(ctx)
+	if err != nil {
+		s.log.Error("get prs needing reviewers", zap.Error(err))
+		return 0, fmt.Errorf("get prs needing reviewers: %w", err)
+	}
+
+	assignedTotal := 0
+	var errs []error
+	for _, candidate := range prs {
+		prID := candidate.PullRequestID
+
+		// Каждый PR доназначается в отдельной транзакции. Список прочитан без блокировок,
+		// поэтому PR перечитывается под блокировкой и пропускается, если его уже слили, закрыли или доукомплектовали
+		var assigned []string
+		err := s.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+			pr, err := s.prRepo.GetByIDForUpdate(ctx, prID)
+			if err != nil {
+				return fmt.Errorf("get pr: %w", err)
+			}
+			if pr.Status != entity.PRStatusOpen || !pr.NeedMoreReviewers {
+				return nil
+			}
+
+			assigned, err = s.backfillPullRequest(ctx, pr)
+			return err
+		})
+		if err != nil {
+			s.log.Error("backfill pr", zap.String("pr_id", prID), zap.Error(err))
+			errs = append(errs, fmt.Errorf("backfill pr %s: %w", prID, err))
+			continue
+		}
+
+		for _, userID := range assigned {
+			s.log.Info("backfilled reviewer", zap.String("pr_id", prID), zap.String("user_id", userID))
+		}
+		assignedTotal += len(assigned)
+	}
+
+	return assignedTotal, errors.Join(errs...)
+}
+
+// backfillPullRequest доназначает на один PR до max_reviewers владельцев измененных файлов и ревьюверов
+// из команды автора и ее резервных команд и возвращает ID назначенных пользователей
+func (s *PullRequestService) backfillPullRequest(ctx context.Context, pr *entity.PullRequest) ([]string, error) {
+	author, err := s.userRepo.GetByID(ctx, pr.AuthorID)
+	if err != nil {
+		return nil, fmt.Errorf("get author: %w", err)
+	}
+
+	pools, err := s.assigner.reviewerPools(ctx, author.TeamName, author.TeamName)
+	if err != nil {
+		return nil, fmt.Errorf("get reviewer pools: %w", err)
+	}
+
+	// Снятые вручную ревьюверы не возвращаются на PR автоматически
+	excluded, err := s.manuallyRemoved(ctx, pr.PullRequestID)
+	if err != nil {
+		return nil, err
+	}
+	excluded[pr.AuthorID] = true
+	for _, reviewerID := range pr.AssignedReviewers {
+		excluded[reviewerID] = true
+	}
+
+	settings, err := s.assigner.reviewerSettings(ctx, author.TeamName)
+	if err != nil {
+		return nil, fmt.Errorf("get reviewer settings: %w", err)
+	}
+
+	reviewers, _, _, err := s.assigner.pickForPullRequest(ctx, pr, author.TeamName, pools, excluded, settings.MaxReviewers-len(pr.AssignedReviewers))
+	if err != nil {
+		return nil, fmt.Errorf("pick reviewers: %w", err)
+	}
+
+	// Флаг мог устареть после изменения настроек команды: тогда он снимается и без новых назначений
+	if len(reviewers) == 0 && needMoreReviewers(len(pr.AssignedReviewers), settings) {
+		return nil, nil
+	}
+
+	assigned := make([]string, 0, len(reviewers))
+	events := make([]entity.ReviewerAssignmentEvent, 0, len(reviewers))
+	for _, reviewer := range reviewers {
+		if err := s.reviewerRepo.AssignReviewer(ctx, pr.PullRequestID, reviewer.UserID); err != nil {
+			return nil, fmt.Errorf("assign reviewer: %w", err)
+		}
+		assigned = append(assigned, reviewer.UserID)
+		events = append(events, newAssignmentEvent(ctx, pr.PullRequestID, entity.AssignmentEventAssign, entity.AssignmentReasonBackfill, reviewer.UserID))
+	}
+	pr.AssignedReviewers = append(pr.AssignedReviewers, assigned...)
+
+	if err := s.eventRepo.AddEvents(ctx, events); err != nil {
+		return nil, fmt.Errorf("add assignment events: %w", err)
+	}
+
+	pr.NeedMoreReviewers = needMoreReviewers(len(pr.AssignedReviewers), settings)
+	if err := s.prRepo.Update(ctx, pr); err != nil {
+		return nil, fmt.Errorf("update pr: %w", err)
+	}
+
+	return assigned, nil
 }
 
-func min(a, b int) int {
-	if a < b {
-		return a
+// checkModifiable проверяет, что состав ревьюверов и решения PR еще можно менять:
+// слитый и закрытый PR заморожены
+func checkModifiable(pr *entity.PullRequest) error {
+	switch pr.Status {
+	case entity.PRStatusMerged:
+		return entity.ErrPRMerged
+	case entity.PRStatusClosed:
+		return entity.ErrPRClosed
 	}
-	return b
+	return nil
+}
+
+// checkVersion сверяет ожидаемую клиентом версию PR с текущей
+func checkVersion(pr *entity.PullRequest, expectedVersion *int) error {
+	if expectedVersion != nil && *expectedVersion != pr.Version {
+		return entity.ErrVersionConflict
+	}
+	return nil
+}
+
+// authorSettings возвращает настройки числа ревьюверов команды автора PR
+func (s *PullRequestService) authorSettings(ctx context.Context, pr *entity.PullRequest) (entity.ReviewerSettings, error) {
+	author, err := s.userRepo.GetByID(ctx, pr.AuthorID)
+	if err != nil {
+		s.log.Error("get author", zap.Error(err))
+		return entity.ReviewerSettings{}, fmt.Errorf("get author: %w", err)
+	}
+
+	settings, err := s.assigner.reviewerSettings(ctx, author.TeamName)
+	if err != nil {
+		s.log.Error("get reviewer settings", zap.Error(err))
+		return entity.ReviewerSettings{}, fmt.Errorf("get reviewer settings: %w", err)
+	}
+
+	return settings, nil
+}
+
+// needMoreReviewers проверяет, меньше ли назначено ревьюверов, чем max_reviewers команды автора:
+// столько назначается автоматически, и PR, получивший меньше, ждет доназначения.
+// Merge при этом ограничивает только min_reviewers
+func needMoreReviewers(assigned int, settings entity.ReviewerSettings) bool {
+	return assigned < settings.MaxReviewers
 }
@@ -0,0 +1,189 @@
+package service_test
+
+import (
+	"context"
+	"fmt"
+	"internship/internal/config"
+	"internship/internal/domain/entity"
+	"internship/internal/repository/memory"
+	"internship/internal/repository/postgres"
+	"internship/internal/repository/sqlite"
+	"internship/internal/service"
+	"internship/migrations"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"go.uber.org/zap"
+)
+
+// postgresDSNEnv — переменная окружения со строкой подключения к отдельной тестовой базе PostgreSQL.
+// Перед каждым использованием таблицы очищаются, поэтому рабочую базу указывать нельзя
+const postgresDSNEnv = "REPOTEST_POSTGRES_DSN"
+
+const queryTruncate = `TRUNCATE teams, users, pull_requests, pull_request_reviewers, audit_log RESTART IDENTITY CASCADE`
+
+// testPostgres — подключение к тестовой базе, общее для всех тестов пакета
+var testPostgres struct {
+	once    sync.Once
+	storage *postgres.Storage
+	err     error
+}
+
+func TestMain(m *testing.M) {
+	code := m.Run()
+	if testPostgres.storage != nil {
+		_ = testPostgres.storage.Close()
+	}
+	os.Exit(code)
+}
+
+// postgresPool подключается к тестовой базе из postgresDSNEnv при первом вызове и применяет миграции.
+// Если переменная не задана, тест пропускается
+func postgresPool(tb testing.TB) *pgxpool.Pool {
+	tb.Helper()
+
+	dsn := os.Getenv(postgresDSNEnv)
+	if dsn == "" {
+		tb.Skipf("%s is not set", postgresDSNEnv)
+	}
+
+	testPostgres.once.Do(func() {
+		ctx := context.Background()
+		storage, err := postgres.NewDatabase(ctx, dsn)
+		if err != nil {
+			testPostgres.err = err
+			return
+		}
+		testPostgres.storage = storage
+
+		migrator, err := postgres.NewMigrator(storage.GetPool(), migrations.FS)
+		if err != nil {
+			testPostgres.err = err
+			return
+		}
+		if _, err := migrator.Up(ctx); err != nil {
+			testPostgres.err = fmt.Errorf("apply migrations: %w", err)
+		}
+	})
+	if testPostgres.err != nil {
+		tb.Fatal(testPostgres.err)
+	}
+	return testPostgres.storage.GetPool()
+}
+
+// testRepositories — репозитории и менеджер транзакций одного хранилища
+type testRepositories struct {
+	team      service.TeamRepositoryInterface
+	user      service.UserRepositoryInterface
+	pr        service.PullRequestRepositoryInterface
+	reviewer  service.ReviewerRepositoryInterface
+	review    service.ReviewDecisionRepositoryInterface
+	change    service.PullRequestChangeRepositoryInterface
+	event     service.AssignmentEventRepositoryInterface
+	audit     service.AuditRepositoryInterface
+	txManager service.TransactionManager
+	// close освобождает хранилище раньше завершения теста; повторный вызов ничего не делает
+	close func()
+}
+
+// newTestRepositories создает пустое хранилище выбранного драйвера.
+// Для PostgreSQL очищается тестовая база из postgresDSNEnv; без нее тест пропускается
+func newTestRepositories(tb testing.TB, driver string) testRepositories {
+	tb.Helper()
+
+	repos := testRepositories{close: func() {}}
+	switch driver {
+	case config.DriverMemory:
+		storage := memory.NewStorage()
+		repos.team, repos.user, repos.pr = memory.NewTeamRepository(storage), memory.NewUserRepository(storage), memory.NewPullRequestRepository(storage)
+		repos.reviewer, repos.review = memory.NewReviewerRepository(storage), memory.NewReviewDecisionRepository(storage)
+		repos.change, repos.event = memory.NewPullRequestChangeRepository(storage), memory.NewAssignmentEventRepository(storage)
+		repos.audit, repos.txManager = memory.NewAuditRepository(storage), memory.NewTxManager(storage)
+	case config.DriverSQLite:
+		storage, err := sqlite.NewDatabase(tb.Context(), filepath.Join(tb.TempDir(), "service.db"))
+		if err != nil {
+			tb.Fatal(err)
+		}
+		repos.close = sync.OnceFunc(func() { _ = storage.Close() })
+		tb.Cleanup(repos.close)
+		db := storage.GetDB()
+		repos.team, repos.user, repos.pr = sqlite.NewTeamRepository(db), sqlite.NewUserRepository(db), sqlite.NewPullRequestRepository(db)
+		repos.reviewer, repos.review = sqlite.NewReviewerRepository(db), sqlite.NewReviewDecisionRepository(db)
+		repos.change, repos.event = sqlite.NewPullRequestChangeRepository(db), sqlite.NewAssignmentEventRepository(db)
+		repos.audit, repos.txManager = sqlite.NewAuditRepository(db), sqlite.NewTxManager(db)
+	case config.DriverPostgres:
+		pool := postgresPool(tb)
+		if _, err := pool.Exec(tb.Context(), queryTruncate); err != nil {
+			tb.Fatalf("truncate tables: %v", err)
+		}
+		repos.team, repos.user, repos.pr = postgres.NewTeamRepository(pool), postgres.NewUserRepository(pool), postgres.NewPullRequestRepository(pool)
+		repos.reviewer, repos.review = postgres.NewReviewerRepository(pool), postgres.NewReviewDecisionRepository(pool)
+		repos.change, repos.event = postgres.NewPullRequestChangeRepository(pool), postgres.NewAssignmentEventRepository(pool)
+		repos.audit, repos.txManager = postgres.NewAuditRepository(pool), postgres.NewTxManager(pool)
+	default:
+		tb.Fatalf("unknown driver %q", driver)
+	}
+	return repos
+}
+
+// createTeam создает команду с участниками prefix_u0..prefix_u{size-1}
+func (r testRepositories) createTeam(tb testing.TB, team *entity.Team, size int) {
+	tb.Helper()
+
+	if err := r.team.Create(tb.Context(), team); err != nil {
+		tb.Fatal(err)
+	}
+	users := make([]*entity.User, 0, size)
+	for i := range size {
+		users = append(users, &entity.User{
+			UserID:   memberID(team.TeamName, i),
+			Username: memberID(team.TeamName, i),
+			TeamName: team.TeamName,
+			IsActive: true,
+		})
+	}
+	if err := r.user.BatchCreateOrUpdate(tb.Context(), users); err != nil {
+		tb.Fatal(err)
+	}
+}
+
+// memberID возвращает ID i-го участника команды, созданной createTeam
+func memberID(teamName string, i int) string {
+	return teamName + "_u" + strconv.Itoa(i)
+}
+
+// selector создает селектор least_loaded над репозиторием ревьюверов
+func (r testRepositories) selector(tb testing.TB) service.ReviewerSelector {
+	tb.Helper()
+
+	selector, err := service.NewReviewerSelector(config.ReviewersConfig{Strategy: service.StrategyLeastLoaded}, r.reviewer)
+	if err != nil {
+		tb.Fatal(err)
+	}
+	return selector
+}
+
+// mustMergePolicy создает политику слияния с настройками по умолчанию
+func mustMergePolicy(tb testing.TB) *service.MergePolicy {
+	tb.Helper()
+
+	mergePolicy, err := service.NewMergePolicy(config.MergeConfig{})
+	if err != nil {
+		tb.Fatal(err)
+	}
+	return mergePolicy
+}
+
+// pullRequestService создает сервис PR над репозиториями со стратегией least_loaded
+func (r testRepositories) pullRequestService(tb testing.TB) *service.PullRequestService {
+	tb.Helper()
+
+	return service.NewPullRequestService(
+		r.pr, r.team, r.user, r.reviewer, r.review, r.change, r.event, r.audit,
+		r.selector(tb), mustMergePolicy(tb), r.txManager, zap.NewNop(),
+	)
+}
@@ -0,0 +1,178 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"internship/internal/domain/entity"
+	"slices"
+
+	"go.uber.org/zap"
+)
+
+// reviewerAssigner содержит общую для сервисов логику отбора и выбора ревьюверов
+type reviewerAssigner struct {
+	teamRepo     TeamRepositoryInterface
+	userRepo     UserRepositoryInterface
+	reviewerRepo ReviewerRepositoryInterface
+	selector     ReviewerSelector
+	log          *zap.Logger
+}
+
+func newReviewerAssigner(
+	teamRepo TeamRepositoryInterface,
+	userRepo UserRepositoryInterface,
+	reviewerRepo ReviewerRepositoryInterface,
+	selector ReviewerSelector,
+	log *zap.Logger,
+) *reviewerAssigner {
+	return &reviewerAssigner{
+		teamRepo:     teamRepo,
+		userRepo:     userRepo,
+		reviewerRepo: reviewerRepo,
+		selector:     selector,
+		log:          log,
+	}
+}
+
+// reviewerSettings возвращает настройки числа ревьюверов на PR авторов команды
+func (a *reviewerAssigner) reviewerSettings(ctx context.Context, teamName string) (entity.ReviewerSettings, error) {
+	team, err := a.team(ctx, teamName)
+	if err != nil {
+		return entity.ReviewerSettings{}, fmt.Errorf("get team: %w", err)
+	}
+	return team.ReviewerSettings, nil
+}
+
+// reviewerPools возвращает команды, из которых по порядку подбираются ревьюверы на PR автора из команды authorTeam:
+// сначала primaryTeam, затем резервные команды authorTeam. Резервные команды резервных команд не используются
+func (a *reviewerAssigner) reviewerPools(ctx context.Context, primaryTeam, authorTeam string) ([]string, error) {
+	fallbackTeams, err := a.fallbackTeams(ctx, authorTeam)
+	if err != nil {
+		return nil, fmt.Errorf("get fallback teams: %w", err)
+	}
+
+	pools := []string{primaryTeam}
+	for _, teamName := range fallbackTeams {
+		if !slices.Contains(pools, teamName) {
+			pools = append(pools, teamName)
+		}
+	}
+	return pools, nil
+}
+
+// filterByCapacity исключает кандидатов, достигших лимита открытых ревью.
+// Лимит пользователя приоритетнее лимита команды; возвращает также число исключенных кандидатов
+func (a *reviewerAssigner) filterByCapacity(ctx context.Context, teamName string, candidates []entity.User) ([]entity.User, int, error) {
+	if len(candidates) == 0 {
+		return candidates, 0, nil
+	}
+
+	team, err := a.team(ctx, teamName)
+	if err != nil {
+		return nil, 0, fmt.Errorf("get team: %w", err)
+	}
+
+	userIDs := make([]string, 0, len(candidates))
+	hasLimits := false
+	for _, candidate := range candidates {
+		userIDs = append(userIDs, candidate.UserID)
+		if candidate.MaxOpenReviews != nil {
+			hasLimits = true
+		}
+	}
+
+	if !hasLimits && team.DefaultMaxOpenReviews == nil {
+		return candidates, 0, nil
+	}
+
+	loads, err := countOpenReviews(ctx, a.reviewerRepo, userIDs)
+	if err != nil {
+		return nil, 0, fmt.Errorf("count open reviews: %w", err)
+	}
+
+	available := make([]entity.User, 0, len(candidates))
+	for _, candidate := range candidates {
+		limit := team.DefaultMaxOpenReviews
+		if candidate.MaxOpenReviews != nil {
+			limit = candidate.MaxOpenReviews
+		}
+		if limit != nil && loads[candidate.UserID] >= *limit {
+			continue
+		}
+		available = append(available, candidate)
+	}
+
+	return available, len(candidates) - len(available), nil
+}
+
+// selectReviewers выбирает ревьюверов из списка кандидатов стратегией, настроенной для команды.
+// Если заданы requiredTags, стратегия сначала выбирает среди кандидатов с наибольшим числом совпавших тегов экспертизы
+// и добирает недостающих из следующих по совпадению
+func (a *reviewerAssigner) selectReviewers(ctx context.Context, teamName string, candidates []entity.User, requiredTags []string, maxCount int) ([]entity.User, error) {
+	if len(candidates) == 0 {
+		a.log.Error("no candidates", zap.Int("max_count", maxCount))
+		return []entity.User{}, nil
+	}
+
+	selected := []entity.User{}
+	for _, tier := range rankByExpertise(candidates, requiredTags) {
+		if len(selected) >= maxCount {
+			break
+		}
+		picked, err := a.selector.Select(ctx, teamName, tier, maxCount-len(selected))
+		if err != nil {
+			return nil, err
+		}
+		selected = append(selected, picked...)
+	}
+
+	a.log.Info("selected reviewers", zap.String("team_name", teamName), zap.Int("count", len(selected)))
+	return selected, nil
+}
+
+// pickFromTeams выбирает до count ревьюверов с учетом требуемых тегов, обходя команды по порядку, пока ревьюверов не хватает.
+// Выбранные пользователи добавляются в excluded. Возвращает также число кандидатов,
+// пропущенных из-за лимита открытых ревью в просмотренных командах
+func (a *reviewerAssigner) pickFromTeams(ctx context.Context, teams []string, excluded map[string]bool, requiredTags []string, count int) ([]entity.User, int, error) {
+	picked := []entity.User{}
+	var atCapacity int
+	for _, teamName := range teams {
+		if len(picked) >= count {
+			break
+		}
+
+		members, err := a.teamMembers(ctx, teamName)
+		if err != nil {
+			return nil, 0, fmt.Errorf("get team %s members: %w", teamName, err)
+		}
+
+		candidates, skipped, err := a.filterByCapacity(ctx, teamName, activeCandidates(members, excluded))
+		if err != nil {
+			return nil, 0, fmt.Errorf("filter by capacity: %w", err)
+		}
+		atCapacity += skipped
+
+		selected, err := a.selectReviewers(ctx, teamName, candidates, requiredTags, count-len(picked))
+		if err != nil {
+			return nil, 0, fmt.Errorf("select reviewers from team %s: %w", teamName, err)
+		}
+		for _, reviewer := range selected {
+			excluded[reviewer.UserID] = true
+		}
+		picked = append(picked, selected...)
+	}
+
+	return picked, atCapacity, nil
+}
+
+// activeCandidates отбирает активных участников команды, кроме исключенных пользователей
+func activeCandidates(members []entity.User, excluded map[string]bool) []entity.User {
+	// Емкость не резервируется: при деактивации большинство участников исключено
+	candidates := []entity.User{}
+	for _, member := range members {
+		if member.IsActive && !excluded[member.UserID] {
+			candidates = append(candidates, member)
+		}
+	}
+	return candidates
+}
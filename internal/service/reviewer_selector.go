@@ -0,0 +1,221 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"internship/internal/config"
+	"internship/internal/domain/entity"
+	"math/rand"
+	"sort"
+	"sync"
+)
+
+// Стратегии выбора ревьюверов
+const (
+	StrategyRandom      = "random"
+	StrategyLeastLoaded = "least_loaded"
+	StrategyRoundRobin  = "round_robin"
+	StrategyWeighted    = "weighted"
+)
+
+// ReviewerSelector выбирает до count ревьюверов из уже отфильтрованных кандидатов команды.
+// При count <= 0 стратегии возвращают nil
+type ReviewerSelector interface {
+	Select(ctx context.Context, teamName string, candidates []entity.User, count int) ([]entity.User, error)
+}
+
+// NewReviewerSelector создает селектор по конфигурации: стратегия сервиса по умолчанию
+// и переопределения для отдельных команд
+func NewReviewerSelector(cfg config.ReviewersConfig, reviewerRepo ReviewerRepositoryInterface) (ReviewerSelector, error) {
+	weights := make(map[string]int, len(cfg.Weights))
+	for _, w := range cfg.Weights {
+		if w.Weight < 1 {
+			return nil, fmt.Errorf("invalid weight %d for user %s", w.Weight, w.UserID)
+		}
+		weights[w.UserID] = w.Weight
+	}
+
+	build := func(strategy string) (ReviewerSelector, error) {
+		switch strategy {
+		case "", StrategyRandom:
+			return &randomSelector{}, nil
+		case StrategyLeastLoaded:
+			return &leastLoadedSelector{reviewerRepo: reviewerRepo}, nil
+		case StrategyRoundRobin:
+			return &roundRobinSelector{lastPicked: make(map[string]string)}, nil
+		case StrategyWeighted:
+			return &weightedSelector{weights: weights}, nil
+		default:
+			return nil, fmt.Errorf("unknown reviewer selection strategy %q", strategy)
+		}
+	}
+
+	defaultSelector, err := build(cfg.Strategy)
+	if err != nil {
+		return nil, err
+	}
+
+	byTeam := make(map[string]ReviewerSelector, len(cfg.Teams))
+	for _, team := range cfg.Teams {
+		selector, err := build(team.Strategy)
+		if err != nil {
+			return nil, fmt.Errorf("team %s: %w", team.TeamName, err)
+		}
+		byTeam[team.TeamName] = selector
+	}
+
+	return &teamSelector{
+		defaultSelector: defaultSelector,
+		byTeam:          byTeam,
+	}, nil
+}
+
+// teamSelector делегирует выбор стратегии, настроенной для команды
+type teamSelector struct {
+	defaultSelector ReviewerSelector
+	byTeam          map[string]ReviewerSelector
+}
+
+func (s *teamSelector) Select(ctx context.Context, teamName string, candidates []entity.User, count int) ([]entity.User, error) {
+	if selector, ok := s.byTeam[teamName]; ok {
+		return selector.Select(ctx, teamName, candidates, count)
+	}
+	return s.defaultSelector.Select(ctx, teamName, candidates, count)
+}
+
+// randomSelector выбирает кандидатов равновероятно
+type randomSelector struct{}
+
+func (s *randomSelector) Select(_ context.Context, _ string, candidates []entity.User, count int) ([]entity.User, error) {
+	if count <= 0 {
+		return nil, nil
+	}
+
+	shuffled := make([]entity.User, len(candidates))
+	copy(shuffled, candidates)
+	rand.Shuffle(len(shuffled), func(i, j int) {
+		shuffled[i], shuffled[j] = shuffled[j], shuffled[i]
+	})
+
+	return shuffled[:min(count, len(shuffled))], nil
+}
+
+// leastLoadedSelector предпочитает кандидатов с наименьшим числом открытых ревью,
+// при равной нагрузке выбирает случайно
+type leastLoadedSelector struct {
+	reviewerRepo ReviewerRepositoryInterface
+}
+
+func (s *leastLoadedSelector) Select(ctx context.Context, _ string, candidates []entity.User, count int) ([]entity.User, error) {
+	if count <= 0 {
+		return nil, nil
+	}
+	if len(candidates) == 0 {
+		return []entity.User{}, nil
+	}
+
+	userIDs := make([]string, 0, len(candidates))
+	for _, candidate := range candidates {
+		userIDs = append(userIDs, candidate.UserID)
+	}
+
+	loads, err := countOpenReviews(ctx, s.reviewerRepo, userIDs)
+	if err != nil {
+		return nil, fmt.Errorf("count open reviews: %w", err)
+	}
+
+	// Перемешиваем перед стабильной сортировкой, чтобы при равной нагрузке выбор был случайным
+	sorted := make([]entity.User, len(candidates))
+	copy(sorted, candidates)
+	rand.Shuffle(len(sorted), func(i, j int) {
+		sorted[i], sorted[j] = sorted[j], sorted[i]
+	})
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return loads[sorted[i].UserID] < loads[sorted[j].UserID]
+	})
+
+	return sorted[:min(count, len(sorted))], nil
+}
+
+// roundRobinSelector выбирает кандидатов по очереди в порядке user_id отдельно для каждой команды
+type roundRobinSelector struct {
+	mu         sync.Mutex
+	lastPicked map[string]string
+}
+
+func (s *roundRobinSelector) Select(_ context.Context, teamName string, candidates []entity.User, count int) ([]entity.User, error) {
+	if count <= 0 {
+		return nil, nil
+	}
+	if len(candidates) == 0 {
+		return []entity.User{}, nil
+	}
+
+	sorted := make([]entity.User, len(candidates))
+	copy(sorted, candidates)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].UserID < sorted[j].UserID
+	})
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// Начинаем с первого кандидата после последнего выбранного в этой команде
+	start := sort.Search(len(sorted), func(i int) bool {
+		return sorted[i].UserID > s.lastPicked[teamName]
+	})
+
+	count = min(count, len(sorted))
+	selected := make([]entity.User, 0, count)
+	for i := 0; i < count; i++ {
+		selected = append(selected, sorted[(start+i)%len(sorted)])
+	}
+	if len(selected) == 0 {
+		return selected, nil
+	}
+	s.lastPicked[teamName] = selected[len(selected)-1].UserID
+
+	return selected, nil
+}
+
+// weightedSelector выбирает кандидатов случайно пропорционально их весам (по умолчанию 1)
+type weightedSelector struct {
+	weights map[string]int
+}
+
+func (s *weightedSelector) Select(_ context.Context, _ string, candidates []entity.User, count int) ([]entity.User, error) {
+	if count <= 0 {
+		return nil, nil
+	}
+
+	remaining := make([]entity.User, len(candidates))
+	copy(remaining, candidates)
+
+	count = min(count, len(remaining))
+	selected := make([]entity.User, 0, count)
+	for len(selected) < count {
+		total := 0
+		for _, candidate := range remaining {
+			total += s.weight(candidate.UserID)
+		}
+
+		pick := rand.Intn(total)
+		for i, candidate := range remaining {
+			pick -= s.weight(candidate.UserID)
+			if pick < 0 {
+				selected = append(selected, candidate)
+				remaining = append(remaining[:i], remaining[i+1:]...)
+				break
+			}
+		}
+	}
+
+	return selected, nil
+}
+
+func (s *weightedSelector) weight(userID string) int {
+	if w, ok := s.weights[userID]; ok {
+		return w
+	}
+	return 1
+}
@@ -0,0 +1,233 @@
+package service
+
+import (
+	"context"
+	"internship/internal/config"
+	"internship/internal/domain/entity"
+	"slices"
+	"testing"
+)
+
+// stubReviewerRepository возвращает заданное число открытых ревью; остальные методы не используются селекторами
+type stubReviewerRepository struct {
+	ReviewerRepositoryInterface
+	loads map[string]int
+}
+
+func (r stubReviewerRepository) CountOpenReviews(_ context.Context, userIDs []string) (map[string]int, error) {
+	counts := make(map[string]int, len(userIDs))
+	for _, userID := range userIDs {
+		counts[userID] = r.loads[userID]
+	}
+	return counts, nil
+}
+
+// users создает активных участников команды с указанными ID
+func users(ids ...string) []entity.User {
+	result := make([]entity.User, 0, len(ids))
+	for _, id := range ids {
+		result = append(result, entity.User{UserID: id, Username: id, TeamName: "backend", IsActive: true})
+	}
+	return result
+}
+
+// userIDs возвращает ID выбранных пользователей в порядке выбора
+func userIDs(selected []entity.User) []string {
+	ids := make([]string, 0, len(selected))
+	for _, user := range selected {
+		ids = append(ids, user.UserID)
+	}
+	return ids
+}
+
+// newSelector создает селектор по конфигурации над заглушкой с заданной нагрузкой
+func newSelector(t *testing.T, cfg config.ReviewersConfig, loads map[string]int) ReviewerSelector {
+	t.Helper()
+
+	selector, err := NewReviewerSelector(cfg, stubReviewerRepository{loads: loads})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return selector
+}
+
+// TestReviewerSelectorCount проверяет для каждой стратегии, что выбираются только переданные кандидаты
+// без повторов и не больше count, а при count <= 0 не выбирается никто
+func TestReviewerSelectorCount(t *testing.T) {
+	strategies := []string{StrategyRandom, StrategyLeastLoaded, StrategyRoundRobin, StrategyWeighted}
+	tests := []struct {
+		name       string
+		candidates []entity.User
+		count      int
+		want       int
+	}{
+		{name: "negative count", candidates: users("u1", "u2"), count: -1, want: 0},
+		{name: "zero count", candidates: users("u1", "u2"), count: 0, want: 0},
+		{name: "no candidates", candidates: nil, count: 2, want: 0},
+		{name: "fewer than candidates", candidates: users("u1", "u2", "u3"), count: 2, want: 2},
+		{name: "count larger than candidates", candidates: users("u1", "u2"), count: 5, want: 2},
+	}
+
+	for _, strategy := range strategies {
+		for _, tt := range tests {
+			t.Run(strategy+"/"+tt.name, func(t *testing.T) {
+				selector := newSelector(t, config.ReviewersConfig{Strategy: strategy}, nil)
+
+				selected, err := selector.Select(t.Context(), "backend", tt.candidates, tt.count)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if len(selected) != tt.want {
+					t.Fatalf("expected %d reviewers, got %v", tt.want, userIDs(selected))
+				}
+
+				candidateIDs := userIDs(tt.candidates)
+				seen := make(map[string]bool, len(selected))
+				for _, user := range selected {
+					if !slices.Contains(candidateIDs, user.UserID) {
+						t.Fatalf("selected %s is not a candidate", user.UserID)
+					}
+					if seen[user.UserID] {
+						t.Fatalf("selected %s twice", user.UserID)
+					}
+					seen[user.UserID] = true
+				}
+			})
+		}
+	}
+}
+
+// TestActiveCandidates проверяет, что из кандидатов исключаются неактивные и исключенные пользователи
+func TestActiveCandidates(t *testing.T) {
+	members := users("u1", "u2", "u3", "u4")
+	members[2].IsActive = false
+
+	tests := []struct {
+		name     string
+		excluded map[string]bool
+		want     []string
+	}{
+		{name: "inactive only", excluded: nil, want: []string{"u1", "u2", "u4"}},
+		{name: "author", excluded: map[string]bool{"u1": true}, want: []string{"u2", "u4"}},
+		{name: "author and current reviewer", excluded: map[string]bool{"u1": true, "u4": true}, want: []string{"u2"}},
+		{name: "everyone", excluded: map[string]bool{"u1": true, "u2": true, "u4": true}, want: []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := userIDs(activeCandidates(members, tt.excluded))
+			if !slices.Equal(got, tt.want) {
+				t.Fatalf("expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+// TestLeastLoadedSelectorOrder проверяет, что выбираются кандидаты с наименьшим числом открытых ревью
+func TestLeastLoadedSelectorOrder(t *testing.T) {
+	tests := []struct {
+		name  string
+		loads map[string]int
+		count int
+		want  []string
+	}{
+		{name: "least loaded first", loads: map[string]int{"u1": 3, "u2": 0, "u3": 1, "u4": 2}, count: 2, want: []string{"u2", "u3"}},
+		{name: "unknown load counts as zero", loads: map[string]int{"u1": 1, "u2": 2, "u3": 4}, count: 1, want: []string{"u4"}},
+		{name: "all candidates by load", loads: map[string]int{"u1": 5, "u2": 4, "u3": 3, "u4": 2}, count: 4, want: []string{"u4", "u3", "u2", "u1"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			selector := newSelector(t, config.ReviewersConfig{Strategy: StrategyLeastLoaded}, tt.loads)
+
+			selected, err := selector.Select(t.Context(), "backend", users("u1", "u2", "u3", "u4"), tt.count)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := userIDs(selected); !slices.Equal(got, tt.want) {
+				t.Fatalf("expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+// TestRoundRobinSelectorRotation проверяет, что очередь ведется отдельно для каждой команды
+// и продолжается после последнего выбранного, даже если состав кандидатов изменился
+func TestRoundRobinSelectorRotation(t *testing.T) {
+	selector := newSelector(t, config.ReviewersConfig{Strategy: StrategyRoundRobin}, nil)
+
+	steps := []struct {
+		team       string
+		candidates []entity.User
+		count      int
+		want       []string
+	}{
+		{team: "backend", candidates: users("u1", "u2", "u3"), count: 2, want: []string{"u1", "u2"}},
+		{team: "frontend", candidates: users("f1", "f2"), count: 1, want: []string{"f1"}},
+		{team: "backend", candidates: users("u1", "u2", "u3"), count: 2, want: []string{"u3", "u1"}},
+		{team: "frontend", candidates: users("f1", "f2"), count: 1, want: []string{"f2"}},
+		{team: "backend", candidates: users("u1", "u3"), count: 1, want: []string{"u3"}},
+		{team: "backend", candidates: users("u1", "u2", "u3"), count: 0, want: []string{}},
+		{team: "backend", candidates: users("u1", "u2", "u3"), count: 1, want: []string{"u1"}},
+		{team: "frontend", candidates: users("f1", "f2"), count: 3, want: []string{"f1", "f2"}},
+	}
+
+	for i, step := range steps {
+		selected, err := selector.Select(t.Context(), step.team, step.candidates, step.count)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := userIDs(selected); !slices.Equal(got, step.want) {
+			t.Fatalf("step %d (%s): expected %v, got %v", i, step.team, step.want, got)
+		}
+	}
+}
+
+// TestWeightedSelector проверяет проверку весов в конфигурации и то,
+// что кандидат с большим весом выбирается заметно чаще
+func TestWeightedSelector(t *testing.T) {
+	tests := []struct {
+		name    string
+		weights []config.ReviewerWeightConfig
+		wantErr bool
+		// favorite — кандидат, которого при count = 1 ожидается выбрать не реже чем в 90% случаев
+		favorite string
+	}{
+		{name: "zero weight", weights: []config.ReviewerWeightConfig{{UserID: "u1", Weight: 0}}, wantErr: true},
+		{name: "negative weight", weights: []config.ReviewerWeightConfig{{UserID: "u1", Weight: -2}}, wantErr: true},
+		{name: "heavy user", weights: []config.ReviewerWeightConfig{{UserID: "u2", Weight: 1000}}, favorite: "u2"},
+		{name: "default weight is one", weights: []config.ReviewerWeightConfig{{UserID: "u1", Weight: 1000}, {UserID: "u3", Weight: 1000}}},
+	}
+
+	const trials = 200
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			selector, err := NewReviewerSelector(config.ReviewersConfig{Strategy: StrategyWeighted, Weights: tt.weights}, nil)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("expected invalid weight error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			picks := make(map[string]int)
+			for range trials {
+				selected, err := selector.Select(t.Context(), "backend", users("u1", "u2", "u3"), 1)
+				if err != nil {
+					t.Fatal(err)
+				}
+				picks[selected[0].UserID]++
+			}
+
+			if tt.favorite != "" && picks[tt.favorite] < trials*9/10 {
+				t.Fatalf("expected %s to be picked at least %d times, got %v", tt.favorite, trials*9/10, picks)
+			}
+			if tt.favorite == "" && picks["u2"] > trials/10 {
+				t.Fatalf("expected u2 with default weight to be picked rarely, got %v", picks)
+			}
+		})
+	}
+}
@@ -0,0 +1,92 @@
+package service_test
+
+import (
+	"internship/internal/config"
+	"internship/internal/domain/entity"
+	"testing"
+)
+
+// TestReviewerShortage создает PR, которому не хватает ревьюверов с настройками команды по умолчанию,
+// и проверяет флаг needMoreReviewers, причину нехватки и фоновое доназначение
+func TestReviewerShortage(t *testing.T) {
+	noCapacity := 0
+	tests := []struct {
+		name   string
+		team   entity.Team
+		size   int
+		reason entity.ShortageReason
+	}{
+		{
+			name:   "author is the only member",
+			team:   entity.Team{TeamName: "solo", ReviewerSettings: entity.DefaultReviewerSettings},
+			size:   1,
+			reason: entity.ShortageNoActiveCandidates,
+		},
+		{
+			name:   "teammates at capacity",
+			team:   entity.Team{TeamName: "busy", ReviewerSettings: entity.DefaultReviewerSettings, DefaultMaxOpenReviews: &noCapacity},
+			size:   3,
+			reason: entity.ShortageCapacityReached,
+		},
+	}
+
+	for _, driver := range []string{config.DriverMemory, config.DriverSQLite} {
+		for _, tt := range tests {
+			t.Run(driver+"/"+tt.name, func(t *testing.T) {
+				repos := newTestRepositories(t, driver)
+				repos.createTeam(t, &tt.team, tt.size)
+				svc := repos.pullRequestService(t)
+
+				pr, err := svc.CreatePullRequest(t.Context(), &entity.PullRequest{
+					PullRequestID:   "short_pr",
+					PullRequestName: "Short",
+					AuthorID:        memberID(tt.team.TeamName, 0),
+				})
+				if err != nil {
+					t.Fatal(err)
+				}
+				if len(pr.AssignedReviewers) != 0 || !pr.NeedMoreReviewers || pr.ShortageReason != tt.reason {
+					t.Fatalf("expected no reviewers, flag and reason %s, got %v, %t, %q", tt.reason, pr.AssignedReviewers, pr.NeedMoreReviewers, pr.ShortageReason)
+				}
+
+				needing, err := svc.GetPullRequestsNeedingReviewers(t.Context())
+				if err != nil {
+					t.Fatal(err)
+				}
+				if len(needing) != 1 || needing[0].PullRequestID != pr.PullRequestID {
+					t.Fatalf("expected %s to need reviewers, got %v", pr.PullRequestID, needing)
+				}
+
+				// Пока кандидатов нет, доназначать некого
+				if assigned, err := svc.BackfillReviewers(t.Context()); err != nil || assigned != 0 {
+					t.Fatalf("backfill without candidates: assigned %d, err %v", assigned, err)
+				}
+
+				// Новые участники без лимита открытых ревью доукомплектовывают PR до max_reviewers
+				unlimited := 10
+				newcomers := []*entity.User{
+					{UserID: tt.team.TeamName + "_new1", Username: "New 1", TeamName: tt.team.TeamName, IsActive: true, MaxOpenReviews: &unlimited},
+					{UserID: tt.team.TeamName + "_new2", Username: "New 2", TeamName: tt.team.TeamName, IsActive: true, MaxOpenReviews: &unlimited},
+				}
+				if err := repos.user.BatchCreateOrUpdate(t.Context(), newcomers); err != nil {
+					t.Fatal(err)
+				}
+				assigned, err := svc.BackfillReviewers(t.Context())
+				if err != nil {
+					t.Fatal(err)
+				}
+				if assigned != entity.DefaultReviewerSettings.MaxReviewers {
+					t.Fatalf("expected %d backfilled reviewers, got %d", entity.DefaultReviewerSettings.MaxReviewers, assigned)
+				}
+
+				needing, err = svc.GetPullRequestsNeedingReviewers(t.Context())
+				if err != nil {
+					t.Fatal(err)
+				}
+				if len(needing) != 0 {
+					t.Fatalf("expected no PRs needing reviewers after backfill, got %v", needing)
+				}
+			})
+		}
+	}
+}
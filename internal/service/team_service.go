@@ -2,6 +2,7 @@ package service
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"internship/internal/domain/entity"
 
@@ -9,47 +10,338 @@ import (
 )
 
 type TeamService struct {
-	teamRepo TeamRepositoryInterface
-	userRepo UserRepositoryInterface
-	log      *zap.Logger
+	teamRepo  TeamRepositoryInterface
+	userRepo  UserRepositoryInterface
+	audit     *auditLog
+	txManager TransactionManager
+	log       *zap.Logger
 }
 
 func NewTeamService(
 	teamRepo TeamRepositoryInterface,
 	userRepo UserRepositoryInterface,
+	auditRepo AuditRepositoryInterface,
+	txManager TransactionManager,
 	log *zap.Logger,
 ) *TeamService {
 	return &TeamService{
-		teamRepo: teamRepo,
-		userRepo: userRepo,
-		log:      log,
+		teamRepo:  teamRepo,
+		userRepo:  userRepo,
+		audit:     newAuditLog(auditRepo, log),
+		txManager: txManager,
+		log:       log,
 	}
 }
 
-// CreateTeam создает команду и добавляет/обновляет участников
+// CreateTeam создает команду и добавляет/обновляет участников в одной транзакции
 func (s *TeamService) CreateTeam(ctx context.Context, team *entity.Team) (*entity.Team, error) {
+	if err := validateReviewerSettings(team.ReviewerSettings); err != nil {
+		s.log.Error("invalid reviewer settings", zap.Error(err))
+		return nil, err
+	}
+
+	var created *entity.Team
+	err := s.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		created, err = s.createTeam(ctx, team)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return created, nil
+}
+
+func (s *TeamService) createTeam(ctx context.Context, team *entity.Team) (*entity.Team, error) {
 
 	if err := s.teamRepo.Create(ctx, team); err != nil {
 		s.log.Error("create team", zap.Error(err))
 		return nil, fmt.Errorf("create team: %w", err)
 	}
+	if len(team.FallbackTeams) > 0 {
+		if err := s.setFallbackTeams(ctx, team.TeamName, team.FallbackTeams); err != nil {
+			return nil, err
+		}
+	}
 	users := make([]*entity.User, 0, len(team.Members))
-	for _, member := range team.Members {
-		users = append(users, &entity.User{
-			UserID:   member.UserID,
-			Username: member.Username,
-			TeamName: team.TeamName,
-			IsActive: member.IsActive,
-		})
+	changes := []auditChange{{
+		action:     entity.AuditActionTeamAdd,
+		entityType: entity.AuditEntityTeam,
+		entityID:   team.TeamName,
+		after:      team,
+	}}
+	for i, member := range team.Members {
+		tags, err := normalizeTags("expertise_tags", member.ExpertiseTags)
+		if err != nil {
+			s.log.Error("invalid expertise tags", zap.String("user_id", member.UserID), zap.Error(err))
+			return nil, err
+		}
+
+		// Участник может уже существовать, например в другой команде
+		existing, err := s.userRepo.GetByID(ctx, member.UserID)
+		if err != nil && !errors.Is(err, entity.ErrUserNotFound) {
+			s.log.Error("get user", zap.Error(err))
+			return nil, fmt.Errorf("get user: %w", err)
+		}
+		// Теги, не переданные в запросе, у существующего пользователя сохраняются
+		if tags == nil {
+			tags = []string{}
+			if existing != nil {
+				tags = existing.ExpertiseTags
+			}
+		}
+		team.Members[i].ExpertiseTags = tags
+
+		user := &entity.User{
+			UserID:         member.UserID,
+			Username:       member.Username,
+			TeamName:       team.TeamName,
+			IsActive:       member.IsActive,
+			MaxOpenReviews: member.MaxOpenReviews,
+			ExpertiseTags:  tags,
+		}
+		users = append(users, user)
+		change := auditChange{
+			action:     entity.AuditActionTeamAdd,
+			entityType: entity.AuditEntityUser,
+			entityID:   member.UserID,
+			after:      user,
+		}
+		if existing != nil {
+			change.before = existing
+		}
+		changes = append(changes, change)
 	}
 	if err := s.userRepo.BatchCreateOrUpdate(ctx, users); err != nil {
 		s.log.Error("batch create or update users", zap.Error(err))
 		return nil, fmt.Errorf("batch create or update users: %w", err)
 	}
 
+	if err := s.audit.record(ctx, changes...); err != nil {
+		return nil, err
+	}
+
 	return team, nil
 }
 
+// UpdateSettings меняет число ревьюверов и резервные команды для PR авторов команды и возвращает новые настройки.
+// Настройки применяются к последующим назначениям и merge, уже назначенные ревьюверы не меняются
+func (s *TeamService) UpdateSettings(ctx context.Context, teamName string, update *entity.TeamSettingsUpdate) (*entity.TeamSettings, error) {
+	var settings *entity.TeamSettings
+	err := s.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		settings, err = s.updateSettings(ctx, teamName, update)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return settings, nil
+}
+
+func (s *TeamService) updateSettings(ctx context.Context, teamName string, update *entity.TeamSettingsUpdate) (*entity.TeamSettings, error) {
+	team, err := s.teamRepo.GetByName(ctx, teamName)
+	if err != nil {
+		s.log.Error("get team", zap.Error(err))
+		return nil, fmt.Errorf("get team: %w", err)
+	}
+	team.FallbackTeams, err = s.teamRepo.GetFallbackTeams(ctx, teamName)
+	if err != nil {
+		s.log.Error("get fallback teams", zap.Error(err))
+		return nil, fmt.Errorf("get fallback teams: %w", err)
+	}
+	before := *team
+
+	if update.MinReviewers != nil {
+		team.MinReviewers = *update.MinReviewers
+	}
+	if update.MaxReviewers != nil {
+		team.MaxReviewers = *update.MaxReviewers
+	}
+	if err := validateReviewerSettings(team.ReviewerSettings); err != nil {
+		s.log.Error("invalid reviewer settings", zap.Error(err))
+		return nil, err
+	}
+	if err := s.teamRepo.UpdateReviewerSettings(ctx, teamName, team.ReviewerSettings); err != nil {
+		s.log.Error("update reviewer settings", zap.Error(err))
+		return nil, fmt.Errorf("update reviewer settings: %w", err)
+	}
+
+	if update.FallbackTeams != nil {
+		team.FallbackTeams = update.FallbackTeams
+		if err := s.setFallbackTeams(ctx, teamName, team.FallbackTeams); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := s.audit.record(ctx, auditChange{
+		action:     entity.AuditActionTeamSettings,
+		entityType: entity.AuditEntityTeam,
+		entityID:   teamName,
+		before:     before,
+		after:      team,
+	}); err != nil {
+		return nil, err
+	}
+
+	s.log.Info("updated team settings", zap.String("team_name", teamName), zap.Any("settings", team.ReviewerSettings), zap.Strings("fallback_teams", team.FallbackTeams))
+	return &entity.TeamSettings{
+		ReviewerSettings: team.ReviewerSettings,
+		FallbackTeams:    team.FallbackTeams,
+	}, nil
+}
+
+// setFallbackTeams проверяет и сохраняет резервные команды: существующие, без повторов и без самой команды
+func (s *TeamService) setFallbackTeams(ctx context.Context, teamName string, fallbackTeams []string) error {
+	if len(fallbackTeams) > entity.MaxFallbackTeams {
+		s.log.Error("too many fallback teams", zap.Int("count", len(fallbackTeams)))
+		return fmt.Errorf("%w: at most %d fallback teams allowed", entity.ErrInvalidInput, entity.MaxFallbackTeams)
+	}
+
+	seen := make(map[string]bool, len(fallbackTeams))
+	for _, fallbackTeam := range fallbackTeams {
+		if fallbackTeam == teamName {
+			s.log.Error("team is its own fallback", zap.String("team_name", teamName))
+			return fmt.Errorf("%w: team cannot be its own fallback", entity.ErrInvalidInput)
+		}
+		if seen[fallbackTeam] {
+			s.log.Error("duplicate fallback team", zap.String("fallback_team", fallbackTeam))
+			return fmt.Errorf("%w: duplicate fallback team %q", entity.ErrInvalidInput, fallbackTeam)
+		}
+		seen[fallbackTeam] = true
+
+		exists, err := s.teamRepo.Exists(ctx, fallbackTeam)
+		if err != nil {
+			s.log.Error("check team exists", zap.Error(err))
+			return fmt.Errorf("check team exists: %w", err)
+		}
+		if !exists {
+			s.log.Error("fallback team not found", zap.String("fallback_team", fallbackTeam))
+			return fmt.Errorf("%w: fallback team %q not found", entity.ErrInvalidInput, fallbackTeam)
+		}
+	}
+
+	if err := s.teamRepo.SetFallbackTeams(ctx, teamName, fallbackTeams); err != nil {
+		s.log.Error("set fallback teams", zap.Error(err))
+		return fmt.Errorf("set fallback teams: %w", err)
+	}
+	return nil
+}
+
+// SetCodeOwners заменяет правила владельцев кода команды. Правила применяются к последующим назначениям
+// ревьюверов на PR авторов команды, уже назначенные ревьюверы не меняются
+func (s *TeamService) SetCodeOwners(ctx context.Context, codeOwners *entity.CodeOwners) (*entity.CodeOwners, error) {
+	if err := validateCodeOwnerRules(codeOwners.Rules); err != nil {
+		s.log.Error("invalid code owner rules", zap.Error(err))
+		return nil, err
+	}
+
+	err := s.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		return s.setCodeOwners(ctx, codeOwners)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return codeOwners, nil
+}
+
+func (s *TeamService) setCodeOwners(ctx context.Context, codeOwners *entity.CodeOwners) error {
+	before, err := s.GetCodeOwners(ctx, codeOwners.TeamName)
+	if err != nil {
+		return err
+	}
+
+	for _, rule := range codeOwners.Rules {
+		for _, ownerID := range rule.Owners {
+			if _, err := s.userRepo.GetByID(ctx, ownerID); err != nil {
+				if errors.Is(err, entity.ErrUserNotFound) {
+					s.log.Error("code owner not found", zap.String("user_id", ownerID))
+					return fmt.Errorf("%w: code owner %q not found", entity.ErrInvalidInput, ownerID)
+				}
+				s.log.Error("get user", zap.Error(err))
+				return fmt.Errorf("get user: %w", err)
+			}
+		}
+	}
+
+	if err := s.teamRepo.SetCodeOwnerRules(ctx, codeOwners.TeamName, codeOwners.Rules); err != nil {
+		s.log.Error("set code owner rules", zap.Error(err))
+		return fmt.Errorf("set code owner rules: %w", err)
+	}
+
+	if err := s.audit.record(ctx, auditChange{
+		action:     entity.AuditActionTeamCodeOwners,
+		entityType: entity.AuditEntityTeam,
+		entityID:   codeOwners.TeamName,
+		before:     before,
+		after:      codeOwners,
+	}); err != nil {
+		return err
+	}
+
+	s.log.Info("set code owner rules", zap.String("team_name", codeOwners.TeamName), zap.Int("rules", len(codeOwners.Rules)))
+	return nil
+}
+
+// GetCodeOwners получает правила владельцев кода команды в порядке применения
+func (s *TeamService) GetCodeOwners(ctx context.Context, teamName string) (*entity.CodeOwners, error) {
+	exists, err := s.teamRepo.Exists(ctx, teamName)
+	if err != nil {
+		s.log.Error("check team exists", zap.Error(err))
+		return nil, fmt.Errorf("check team exists: %w", err)
+	}
+	if !exists {
+		s.log.Error("team not found", zap.String("team_name", teamName))
+		return nil, entity.ErrTeamNotFound
+	}
+
+	rules, err := s.teamRepo.GetCodeOwnerRules(ctx, teamName)
+	if err != nil {
+		s.log.Error("get code owner rules", zap.Error(err))
+		return nil, fmt.Errorf("get code owner rules: %w", err)
+	}
+
+	return &entity.CodeOwners{TeamName: teamName, Rules: rules}, nil
+}
+
+// validateCodeOwnerRules проверяет число правил, их шаблоны и списки владельцев
+func validateCodeOwnerRules(rules []entity.CodeOwnerRule) error {
+	if len(rules) > entity.MaxCodeOwnerRules {
+		return fmt.Errorf("%w: at most %d code owner rules allowed", entity.ErrInvalidInput, entity.MaxCodeOwnerRules)
+	}
+
+	for _, rule := range rules {
+		if err := validateCodeOwnerPattern(rule.Pattern); err != nil {
+			return err
+		}
+		if len(rule.Owners) == 0 || len(rule.Owners) > entity.MaxRuleOwners {
+			return fmt.Errorf("%w: rule %q must have between 1 and %d owners", entity.ErrInvalidInput, rule.Pattern, entity.MaxRuleOwners)
+		}
+		seen := make(map[string]bool, len(rule.Owners))
+		for _, ownerID := range rule.Owners {
+			if seen[ownerID] {
+				return fmt.Errorf("%w: duplicate owner %q in rule %q", entity.ErrInvalidInput, ownerID, rule.Pattern)
+			}
+			seen[ownerID] = true
+		}
+	}
+	return nil
+}
+
+// validateReviewerSettings проверяет границы числа ревьюверов команды
+func validateReviewerSettings(settings entity.ReviewerSettings) error {
+	if settings.MaxReviewers < 1 || settings.MaxReviewers > entity.MaxReviewersLimit {
+		return fmt.Errorf("%w: max_reviewers must be between 1 and %d", entity.ErrInvalidInput, entity.MaxReviewersLimit)
+	}
+	if settings.MinReviewers < 0 || settings.MinReviewers > settings.MaxReviewers {
+		return fmt.Errorf("%w: min_reviewers must be between 0 and max_reviewers", entity.ErrInvalidInput)
+	}
+	return nil
+}
+
 // IsTeamExists проверяет существование команды
 func (s *TeamService) IsTeamExists(ctx context.Context, teamName string) (bool, error) {
 	exists, err := s.teamRepo.Exists(ctx, teamName)
@@ -69,6 +361,12 @@ func (s *TeamService) GetTeam(ctx context.Context, teamName string) (*entity.Tea
 		return nil, fmt.Errorf("get team: %w", err)
 	}
 
+	team.FallbackTeams, err = s.teamRepo.GetFallbackTeams(ctx, teamName)
+	if err != nil {
+		s.log.Error("get fallback teams", zap.Error(err))
+		return nil, fmt.Errorf("get fallback teams: %w", err)
+	}
+
 	users, err := s.userRepo.GetByTeamName(ctx, teamName)
 	if err != nil {
 		s.log.Error("get team members", zap.Error(err))
@@ -78,9 +376,11 @@ func (s *TeamService) GetTeam(ctx context.Context, teamName string) (*entity.Tea
 	team.Members = make([]entity.TeamMember, 0, len(users))
 	for _, user := range users {
 		team.Members = append(team.Members, entity.TeamMember{
-			UserID:   user.UserID,
-			Username: user.Username,
-			IsActive: user.IsActive,
+			UserID:         user.UserID,
+			Username:       user.Username,
+			IsActive:       user.IsActive,
+			MaxOpenReviews: user.MaxOpenReviews,
+			ExpertiseTags:  user.ExpertiseTags,
 		})
 	}
 
@@ -4,6 +4,8 @@ import (
 	"context"
 	"fmt"
 	"internship/internal/domain/entity"
+	"maps"
+	"slices"
 	"time"
 
 	"go.uber.org/zap"
@@ -13,32 +15,64 @@ type UserService struct {
 	userRepo     UserRepositoryInterface
 	prRepo       PullRequestRepositoryInterface
 	reviewerRepo ReviewerRepositoryInterface
+	eventRepo    AssignmentEventRepositoryInterface
+	audit        *auditLog
+	assigner     *reviewerAssigner
+	txManager    TransactionManager
+	fallbackTeam string
 	log          *zap.Logger
 }
 
-// NewUserService создает новый сервис пользователей
+// NewUserService создает новый сервис пользователей.
+// fallbackTeam — команда, из которой берутся замены при деактивации, если в команде автора PR их не хватает
 func NewUserService(
 	userRepo UserRepositoryInterface,
+	teamRepo TeamRepositoryInterface,
 	prRepo PullRequestRepositoryInterface,
 	reviewerRepo ReviewerRepositoryInterface,
+	eventRepo AssignmentEventRepositoryInterface,
+	auditRepo AuditRepositoryInterface,
+	selector ReviewerSelector,
+	txManager TransactionManager,
+	fallbackTeam string,
 	log *zap.Logger,
 ) *UserService {
 	return &UserService{
 		userRepo:     userRepo,
 		prRepo:       prRepo,
 		reviewerRepo: reviewerRepo,
+		eventRepo:    eventRepo,
+		audit:        newAuditLog(auditRepo, log),
+		assigner:     newReviewerAssigner(teamRepo, userRepo, reviewerRepo, selector, log),
+		txManager:    txManager,
+		fallbackTeam: fallbackTeam,
 		log:          log,
 	}
 }
 
-// SetIsActive устанавливает флаг активности пользователя
+// SetIsActive устанавливает флаг активности пользователя.
+// Изменение и запись в журнал аудита выполняются в одной транзакции
 func (s *UserService) SetIsActive(ctx context.Context, userID string, isActive bool) (*entity.User, error) {
+	var user *entity.User
+	err := s.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		user, err = s.setIsActive(ctx, userID, isActive)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return user, nil
+}
 
+func (s *UserService) setIsActive(ctx context.Context, userID string, isActive bool) (*entity.User, error) {
 	user, err := s.userRepo.GetByID(ctx, userID)
 	if err != nil {
 		s.log.Error("get user", zap.Error(err))
 		return nil, fmt.Errorf("get user: %w", err)
 	}
+	before := *user
 
 	if err := s.userRepo.SetIsActive(ctx, userID, isActive); err != nil {
 		s.log.Error("set is_active", zap.Error(err))
@@ -47,6 +81,71 @@ func (s *UserService) SetIsActive(ctx context.Context, userID string, isActive b
 
 	user.IsActive = isActive
 
+	err = s.audit.record(ctx, auditChange{
+		action:     entity.AuditActionSetIsActive,
+		entityType: entity.AuditEntityUser,
+		entityID:   userID,
+		before:     before,
+		after:      user,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return user, nil
+}
+
+// SetExpertiseTags заменяет теги экспертизы пользователя; пустой список удаляет их.
+// Изменение и запись в журнал аудита выполняются в одной транзакции
+func (s *UserService) SetExpertiseTags(ctx context.Context, userID string, tags []string) (*entity.User, error) {
+	tags, err := normalizeTags("expertise_tags", tags)
+	if err != nil {
+		s.log.Error("invalid expertise tags", zap.Error(err))
+		return nil, err
+	}
+	if tags == nil {
+		tags = []string{}
+	}
+
+	var user *entity.User
+	err = s.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		user, err = s.setExpertiseTags(ctx, userID, tags)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return user, nil
+}
+
+func (s *UserService) setExpertiseTags(ctx context.Context, userID string, tags []string) (*entity.User, error) {
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		s.log.Error("get user", zap.Error(err))
+		return nil, fmt.Errorf("get user: %w", err)
+	}
+	before := *user
+
+	if err := s.userRepo.SetExpertiseTags(ctx, userID, tags); err != nil {
+		s.log.Error("set expertise tags", zap.Error(err))
+		return nil, fmt.Errorf("set expertise tags: %w", err)
+	}
+
+	user.ExpertiseTags = tags
+
+	err = s.audit.record(ctx, auditChange{
+		action:     entity.AuditActionSetExpertise,
+		entityType: entity.AuditEntityUser,
+		entityID:   userID,
+		before:     before,
+		after:      user,
+	})
+	if err != nil {
+		return nil, err
+	}
+
 	return user, nil
 }
 
@@ -68,15 +167,32 @@ func (s *UserService) GetReviewPullRequests(ctx context.Context, userID string)
 	return shortPRs, nil
 }
 
-// DeactivateTeamMembers деактивирует всех участников команды и переназначает открытые PR
-// Оптимизировано для выполнения за ~100ms
-func (s *UserService) DeactivateTeamMembers(ctx context.Context, teamName string) ([]entity.PullRequest, error) {
+// DeactivateTeamMembers деактивирует всех участников команды и переназначает открытые PR.
+// Замены подбираются из команды автора PR, а при их нехватке — из резервной команды.
+// Замены, выбранные для предыдущих PR, учитываются в нагрузке и лимитах открытых ревью следующих.
+// Деактивация и все замены применяются в одной транзакции с таймаутом 100ms;
+// время для команды из 200 участников с 10–300 открытыми PR замеряет BenchmarkDeactivateTeamMembers
+func (s *UserService) DeactivateTeamMembers(ctx context.Context, teamName string) ([]entity.ReviewerReassignment, error) {
 	// Устанавливаем таймаут для операции
 	ctx, cancel := context.WithTimeout(ctx, 100*time.Millisecond)
 	defer cancel()
 
-	// Получаем всех пользователей команды
-	teamMembers, err := s.userRepo.GetByTeamName(ctx, teamName)
+	var reassignments []entity.ReviewerReassignment
+	err := s.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		reassignments, err = s.deactivateTeamMembers(withAssignmentPlan(ctx), teamName)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return reassignments, nil
+}
+
+func (s *UserService) deactivateTeamMembers(ctx context.Context, teamName string) ([]entity.ReviewerReassignment, error) {
+	// Получаем всех пользователей команды; они же попадают в план для подбора замен
+	teamMembers, err := s.assigner.teamMembers(ctx, teamName)
 	if err != nil {
 		s.log.Error("get team members", zap.Error(err))
 		return nil, fmt.Errorf("get team members: %w", err)
@@ -84,13 +200,15 @@ func (s *UserService) DeactivateTeamMembers(ctx context.Context, teamName string
 
 	if len(teamMembers) == 0 {
 		s.log.Info("no team members", zap.String("team_name", teamName))
-		return []entity.PullRequest{}, nil
+		return []entity.ReviewerReassignment{}, nil
 	}
 
 	// Собираем ID всех участников команды
 	memberIDs := make([]string, 0, len(teamMembers))
+	deactivated := make(map[string]bool, len(teamMembers))
 	for _, member := range teamMembers {
 		memberIDs = append(memberIDs, member.UserID)
+		deactivated[member.UserID] = true
 	}
 
 	// Получаем все открытые PR, где участники команды назначены ревьюверами
@@ -100,39 +218,139 @@ func (s *UserService) DeactivateTeamMembers(ctx context.Context, teamName string
 		return nil, fmt.Errorf("get open prs: %w", err)
 	}
 
-	// Деактивируем всех участников команды
-	if err := s.userRepo.DeactivateTeamMembers(ctx, teamName); err != nil {
+	reassignments := make([]entity.ReviewerReassignment, 0, len(openPRs))
+	for _, pr := range openPRs {
+		reassignment, err := s.planReassignment(ctx, pr, deactivated)
+		if err != nil {
+			s.log.Error("plan reassignment", zap.String("pr_id", pr.PullRequestID), zap.Error(err))
+			return nil, fmt.Errorf("plan reassignment for pr %s: %w", pr.PullRequestID, err)
+		}
+		reassignments = append(reassignments, reassignment)
+	}
+
+	// Деактивируем участников и применяем замены
+	if err := s.userRepo.DeactivateTeamMembers(ctx, teamName, reassignments); err != nil {
 		s.log.Error("deactivate team members", zap.Error(err))
 		return nil, fmt.Errorf("deactivate team members: %w", err)
 	}
 
-	// Для каждого открытого PR удаляем всех ревьюверов из деактивированной команды
-	for i := range openPRs {
-		pr := &openPRs[i]
-
-		// Удаляем ревьюверов, которые были в деактивированной команде
-		newReviewers := make([]string, 0, len(pr.AssignedReviewers))
-		for _, reviewerID := range pr.AssignedReviewers {
-			isMember := false
-			for _, memberID := range memberIDs {
-				if reviewerID == memberID {
-					isMember = true
-					// Удаляем ревьювера
-					if err := s.reviewerRepo.RemoveReviewer(ctx, pr.PullRequestID, reviewerID); err != nil {
-						s.log.Error("remove reviewer", zap.Error(err))
-						return nil, fmt.Errorf("remove reviewer: %w", err)
-					}
-					break
-				}
-			}
-			if !isMember {
-				newReviewers = append(newReviewers, reviewerID)
-			}
+	// Записываем замены в историю назначений
+	var events []entity.ReviewerAssignmentEvent
+	for _, reassignment := range reassignments {
+		events = append(events, reassignmentEvents(ctx, reassignment, entity.AssignmentReasonTeamDeactivation)...)
+	}
+	if len(events) > 0 {
+		if err := s.eventRepo.AddEvents(ctx, events); err != nil {
+			s.log.Error("add assignment events", zap.Error(err))
+			return nil, fmt.Errorf("add assignment events: %w", err)
 		}
+	}
+
+	if err := s.audit.record(ctx, deactivationAuditChanges(teamMembers, openPRs, reassignments)...); err != nil {
+		return nil, err
+	}
+
+	s.log.Info("deactivate team members", zap.Any("reassignments", reassignments))
+	return reassignments, nil
+}
+
+// planReassignment убирает с PR деактивируемых ревьюверов и подбирает им замены: сначала владельцев измененных файлов,
+// затем из команды автора, из ее резервных команд и, наконец, из резервной команды конфигурации.
+// Команды, авторы и правила владельцев кода берутся из плана назначений контекста
+func (s *UserService) planReassignment(ctx context.Context, pr entity.PullRequest, deactivated map[string]bool) (entity.ReviewerReassignment, error) {
+	reassignment := entity.ReviewerReassignment{
+		RemovedReviewers: []string{},
+		AddedReviewers:   []string{},
+	}
+
+	// Исключаем деактивируемых, автора и оставшихся ревьюверов
+	excluded := maps.Clone(deactivated)
+	excluded[pr.AuthorID] = true
+
+	kept := make([]string, 0, len(pr.AssignedReviewers))
+	for _, reviewerID := range pr.AssignedReviewers {
+		excluded[reviewerID] = true
+		if deactivated[reviewerID] {
+			reassignment.RemovedReviewers = append(reassignment.RemovedReviewers, reviewerID)
+		} else {
+			kept = append(kept, reviewerID)
+		}
+	}
+
+	author, err := s.assigner.user(ctx, pr.AuthorID)
+	if err != nil {
+		return reassignment, fmt.Errorf("get author: %w", err)
+	}
+
+	sourceTeams, err := s.assigner.reviewerPools(ctx, author.TeamName, author.TeamName)
+	if err != nil {
+		return reassignment, fmt.Errorf("get reviewer pools: %w", err)
+	}
+	if s.fallbackTeam != "" && !slices.Contains(sourceTeams, s.fallbackTeam) {
+		sourceTeams = append(sourceTeams, s.fallbackTeam)
+	}
+
+	settings, err := s.assigner.reviewerSettings(ctx, author.TeamName)
+	if err != nil {
+		return reassignment, fmt.Errorf("get reviewer settings: %w", err)
+	}
 
-		pr.AssignedReviewers = newReviewers
+	need := min(len(reassignment.RemovedReviewers), settings.MaxReviewers-len(kept))
+	owners, matched, err := s.assigner.pickCodeOwners(ctx, author.TeamName, &pr, excluded, need)
+	if err != nil {
+		return reassignment, fmt.Errorf("pick code owners: %w", err)
+	}
+	for _, reviewer := range owners {
+		reassignment.AddedReviewers = append(reassignment.AddedReviewers, reviewer.UserID)
+		setReviewerTeam(&pr, reviewer)
 	}
+	setReviewerRules(&pr, matched)
+	need -= len(owners)
 
-	s.log.Info("deactivate team members", zap.Any("open_prs", openPRs))
-	return openPRs, nil
+	picked, _, err := s.assigner.pickFromTeams(ctx, sourceTeams, excluded, pr.RequiredTags, need)
+	if err != nil {
+		return reassignment, fmt.Errorf("pick reviewers: %w", err)
+	}
+	for _, reviewer := range picked {
+		reassignment.AddedReviewers = append(reassignment.AddedReviewers, reviewer.UserID)
+		setReviewerTeam(&pr, reviewer)
+	}
+
+	// Замены записываются в конце деактивации, поэтому их нагрузка учитывается планом для следующих PR
+	if err := planAssignments(ctx, s.reviewerRepo, reassignment.AddedReviewers); err != nil {
+		return reassignment, fmt.Errorf("plan assignments: %w", err)
+	}
+
+	pr.AssignedReviewers = append(kept, reassignment.AddedReviewers...)
+	pr.NeedMoreReviewers = needMoreReviewers(len(pr.AssignedReviewers), settings)
+	reassignment.PullRequest = pr
+
+	return reassignment, nil
+}
+
+// deactivationAuditChanges описывает для журнала аудита деактивацию участников команды
+// и изменение состава ревьюверов затронутых PR
+func deactivationAuditChanges(members []entity.User, openPRs []entity.PullRequest, reassignments []entity.ReviewerReassignment) []auditChange {
+	changes := make([]auditChange, 0, len(members)+len(reassignments))
+	for _, member := range members {
+		deactivated := member
+		deactivated.IsActive = false
+		changes = append(changes, auditChange{
+			action:     entity.AuditActionDeactivateTeam,
+			entityType: entity.AuditEntityUser,
+			entityID:   member.UserID,
+			before:     member,
+			after:      deactivated,
+		})
+	}
+	for i, reassignment := range reassignments {
+		changes = append(changes, auditChange{
+			action:     entity.AuditActionDeactivateTeam,
+			entityType: entity.AuditEntityPullRequest,
+			entityID:   reassignment.PullRequest.PullRequestID,
+			before:     openPRs[i],
+			after:      reassignment.PullRequest,
+		})
+	}
+	return changes
 }
@@ -0,0 +1,52 @@
+package worker
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// AuditPurger удаляет записи журнала аудита старше срока хранения
+type AuditPurger interface {
+	PurgeExpired(ctx context.Context) (int64, error)
+}
+
+// AuditRetentionWorker периодически удаляет устаревшие записи журнала аудита
+type AuditRetentionWorker struct {
+	purger   AuditPurger
+	interval time.Duration
+	log      *zap.Logger
+}
+
+func NewAuditRetentionWorker(purger AuditPurger, interval time.Duration, log *zap.Logger) *AuditRetentionWorker {
+	return &AuditRetentionWorker{
+		purger:   purger,
+		interval: interval,
+		log:      log,
+	}
+}
+
+// Run удаляет устаревшие записи с заданным интервалом до отмены контекста
+func (w *AuditRetentionWorker) Run(ctx context.Context) {
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	w.log.Info("Audit retention worker started", zap.Duration("interval", w.interval))
+	for {
+		select {
+		case <-ctx.Done():
+			w.log.Info("Audit retention worker stopped")
+			return
+		case <-ticker.C:
+			deleted, err := w.purger.PurgeExpired(ctx)
+			if err != nil {
+				w.log.Error("Audit retention failed", zap.Error(err))
+				continue
+			}
+			if deleted > 0 {
+				w.log.Info("Deleted expired audit records", zap.Int64("deleted", deleted))
+			}
+		}
+	}
+}
@@ -0,0 +1,52 @@
+package worker
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// ReviewerBackfiller доназначает ревьюверов на PR, которым их не хватает
+type ReviewerBackfiller interface {
+	BackfillReviewers(ctx context.Context) (int, error)
+}
+
+// BackfillWorker периодически запускает доназначение ревьюверов
+type BackfillWorker struct {
+	backfiller ReviewerBackfiller
+	interval   time.Duration
+	log        *zap.Logger
+}
+
+func NewBackfillWorker(backfiller ReviewerBackfiller, interval time.Duration, log *zap.Logger) *BackfillWorker {
+	return &BackfillWorker{
+		backfiller: backfiller,
+		interval:   interval,
+		log:        log,
+	}
+}
+
+// Run выполняет доназначение с заданным интервалом до отмены контекста
+func (w *BackfillWorker) Run(ctx context.Context) {
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	w.log.Info("Backfill worker started", zap.Duration("interval", w.interval))
+	for {
+		select {
+		case <-ctx.Done():
+			w.log.Info("Backfill worker stopped")
+			return
+		case <-ticker.C:
+			assigned, err := w.backfiller.BackfillReviewers(ctx)
+			if err != nil {
+				w.log.Error("Backfill failed", zap.Error(err))
+				continue
+			}
+			if assigned > 0 {
+				w.log.Info("Backfill assigned reviewers", zap.Int("assigned", assigned))
+			}
+		}
+	}
+}
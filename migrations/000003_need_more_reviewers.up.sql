@@ -0,0 +1,4 @@
+-- Flag for PRs that got fewer reviewers than required
+ALTER TABLE pull_requests ADD COLUMN IF NOT EXISTS need_more_reviewers BOOLEAN NOT NULL DEFAULT false;
+
+CREATE INDEX IF NOT EXISTS idx_pull_requests_need_more_reviewers ON pull_requests(created_at) WHERE status = 'OPEN' AND need_more_reviewers;
@@ -0,0 +1,2 @@
+-- Optimistic locking version of a pull request
+ALTER TABLE pull_requests ADD COLUMN IF NOT EXISTS version INTEGER NOT NULL DEFAULT 1;
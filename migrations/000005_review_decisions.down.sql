@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_review_decisions_pull_request_id;
+
+DROP TABLE IF EXISTS review_decisions;
@@ -0,0 +1,12 @@
+-- The old schema has no state for drafts or closed PRs. Rewriting them as OPEN would make them eligible
+-- for reviewer assignment again, so the rollback is refused until such rows are resolved by hand
+DO $$
+BEGIN
+    IF EXISTS (SELECT 1 FROM pull_requests WHERE status IN ('DRAFT', 'CLOSED')) THEN
+        RAISE EXCEPTION 'cannot roll back pull request lifecycle: DRAFT or CLOSED pull requests exist';
+    END IF;
+END
+$$;
+
+ALTER TABLE pull_requests DROP CONSTRAINT IF EXISTS pull_requests_status_check;
+ALTER TABLE pull_requests ADD CONSTRAINT pull_requests_status_check CHECK (status IN ('OPEN', 'MERGED'));
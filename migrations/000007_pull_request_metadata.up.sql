@@ -0,0 +1,17 @@
+-- Editable pull request metadata
+ALTER TABLE pull_requests ADD COLUMN IF NOT EXISTS description TEXT NOT NULL DEFAULT '';
+ALTER TABLE pull_requests ADD COLUMN IF NOT EXISTS labels JSONB NOT NULL DEFAULT '[]';
+ALTER TABLE pull_requests ADD COLUMN IF NOT EXISTS metadata JSONB NOT NULL DEFAULT '{}';
+
+-- History of pull request edits with previous and new values
+CREATE TABLE IF NOT EXISTS pull_request_changes (
+    id BIGSERIAL PRIMARY KEY,
+    pull_request_id VARCHAR(255) NOT NULL,
+    field VARCHAR(64) NOT NULL,
+    old_value JSONB NOT NULL,
+    new_value JSONB NOT NULL,
+    changed_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    FOREIGN KEY (pull_request_id) REFERENCES pull_requests(pull_request_id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_pull_request_changes_pull_request_id ON pull_request_changes(pull_request_id, id);
@@ -0,0 +1,20 @@
+-- Append-only history of reviewer assignments
+CREATE TABLE IF NOT EXISTS reviewer_assignment_events (
+    id BIGSERIAL PRIMARY KEY,
+    pull_request_id VARCHAR(255) NOT NULL,
+    event_type VARCHAR(20) NOT NULL CHECK (event_type IN ('ASSIGN', 'UNASSIGN', 'REPLACE')),
+    user_id VARCHAR(255) NOT NULL,
+    previous_user_id VARCHAR(255) NOT NULL DEFAULT '',
+    reason VARCHAR(32) NOT NULL,
+    actor VARCHAR(255) NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    FOREIGN KEY (pull_request_id) REFERENCES pull_requests(pull_request_id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_reviewer_assignment_events_pull_request_id ON reviewer_assignment_events(pull_request_id, id);
+
+-- Current assignments are kept as the starting point of the history
+INSERT INTO reviewer_assignment_events (pull_request_id, event_type, user_id, reason, actor, created_at)
+SELECT pull_request_id, 'ASSIGN', user_id, 'IMPORTED', 'system', assigned_at
+FROM pull_request_reviewers
+ORDER BY assigned_at;
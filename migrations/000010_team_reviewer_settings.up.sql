@@ -0,0 +1,5 @@
+-- Number of reviewers on pull requests of the team's authors:
+-- max_reviewers are assigned automatically, min_reviewers are required for merge
+ALTER TABLE teams ADD COLUMN IF NOT EXISTS max_reviewers INTEGER NOT NULL DEFAULT 2 CHECK (max_reviewers BETWEEN 1 AND 10);
+ALTER TABLE teams ADD COLUMN IF NOT EXISTS min_reviewers INTEGER NOT NULL DEFAULT 0 CHECK (min_reviewers >= 0);
+ALTER TABLE teams ADD CONSTRAINT teams_reviewer_settings_check CHECK (min_reviewers <= max_reviewers);
@@ -0,0 +1,9 @@
+-- Ordered fallback teams that supply reviewers when the author's team has too few candidates
+CREATE TABLE IF NOT EXISTS team_fallbacks (
+    team_name VARCHAR(255) NOT NULL REFERENCES teams(team_name) ON DELETE CASCADE,
+    position INTEGER NOT NULL CHECK (position >= 0),
+    fallback_team_name VARCHAR(255) NOT NULL REFERENCES teams(team_name) ON DELETE CASCADE,
+    PRIMARY KEY (team_name, position),
+    UNIQUE (team_name, fallback_team_name),
+    CHECK (fallback_team_name <> team_name)
+);
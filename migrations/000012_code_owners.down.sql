@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS code_owner_rules;
+
+ALTER TABLE pull_requests DROP COLUMN IF EXISTS changed_files;
@@ -0,0 +1,11 @@
+-- Paths changed by a pull request, used to match code ownership rules
+ALTER TABLE pull_requests ADD COLUMN IF NOT EXISTS changed_files JSONB NOT NULL DEFAULT '[]';
+
+-- Ordered CODEOWNERS-style rules of a team; the last rule matching a file wins
+CREATE TABLE IF NOT EXISTS code_owner_rules (
+    team_name VARCHAR(255) NOT NULL REFERENCES teams(team_name) ON DELETE CASCADE,
+    position INTEGER NOT NULL CHECK (position >= 0),
+    pattern VARCHAR(1024) NOT NULL,
+    owners JSONB NOT NULL,
+    PRIMARY KEY (team_name, position)
+);
@@ -0,0 +1,3 @@
+-- Reviewer expertise tags and the tags a pull request requires from its reviewers
+ALTER TABLE users ADD COLUMN IF NOT EXISTS expertise_tags JSONB NOT NULL DEFAULT '[]';
+ALTER TABLE pull_requests ADD COLUMN IF NOT EXISTS required_tags JSONB NOT NULL DEFAULT '[]';
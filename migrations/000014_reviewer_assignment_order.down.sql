@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_pull_request_reviewers_order;
+ALTER TABLE pull_request_reviewers DROP COLUMN IF EXISTS id;
+ALTER TABLE pull_request_reviewers ALTER COLUMN assigned_at SET DEFAULT NOW();
@@ -0,0 +1,5 @@
+-- NOW() is the transaction start time, so reviewers inserted by one create or reassign share a timestamp.
+-- clock_timestamp() and a monotonic id make the assignment order deterministic
+ALTER TABLE pull_request_reviewers ALTER COLUMN assigned_at SET DEFAULT clock_timestamp();
+ALTER TABLE pull_request_reviewers ADD COLUMN IF NOT EXISTS id BIGSERIAL;
+CREATE INDEX IF NOT EXISTS idx_pull_request_reviewers_order ON pull_request_reviewers(pull_request_id, assigned_at, id);
@@ -0,0 +1,8 @@
+// Package migrations встраивает SQL-миграции PostgreSQL в бинарник.
+// Файлы именуются в формате golang-migrate: <версия>_<название>.up.sql / .down.sql
+package migrations
+
+import "embed"
+
+//go:embed *.sql
+var FS embed.FS
@@ -2,7 +2,7 @@
 set -e
 
 echo "Running migrations..."
-migrate -path ./migrations -database "${DB_URL}" up
+/app/app migrate up
 
 
 